@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+	"github.com/google/uuid"
+)
+
+// resourceCommands are the commands available for every resource
+type resourceCommands interface {
+	list(ctx context.Context, options ...hawapi.QueryOptions) (any, []any, error)
+	find(ctx context.Context, id uuid.UUID) (any, error)
+	random(ctx context.Context) (any, error)
+	create(ctx context.Context, body []byte) (any, error)
+	patch(ctx context.Context, id uuid.UUID, body []byte) (any, error)
+	delete(ctx context.Context, id uuid.UUID) error
+}
+
+// resource adapts a hawapi.Resource to resourceCommands
+type resource[T, C, P any] struct {
+	hawapi.Resource[T, C, P]
+}
+
+func (r resource[T, C, P]) list(ctx context.Context, options ...hawapi.QueryOptions) (any, []any, error) {
+	res, err := r.List(ctx, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]any, len(res.Data))
+	for i, item := range res.Data {
+		items[i] = item
+	}
+
+	return res, items, nil
+}
+
+func (r resource[T, C, P]) find(ctx context.Context, id uuid.UUID) (any, error) {
+	res, err := r.Find(ctx, id)
+	return res.Data, err
+}
+
+func (r resource[T, C, P]) random(ctx context.Context) (any, error) {
+	res, err := r.Random(ctx)
+	return res.Data, err
+}
+
+func (r resource[T, C, P]) create(ctx context.Context, body []byte) (any, error) {
+	var in C
+	if err := json.Unmarshal(body, &in); err != nil {
+		return nil, fmt.Errorf("invalid item: %w", err)
+	}
+
+	return r.Create(ctx, in)
+}
+
+func (r resource[T, C, P]) patch(ctx context.Context, id uuid.UUID, body []byte) (any, error) {
+	var patch P
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	return r.Patch(ctx, id, patch)
+}
+
+func (r resource[T, C, P]) delete(ctx context.Context, id uuid.UUID) error {
+	return r.Delete(ctx, id)
+}
+
+// resources returns the commands of a resource, using its plural or singular name
+func resources(c *hawapi.Client, name string) (resourceCommands, bool) {
+	switch strings.TrimSuffix(strings.ToLower(name), "s") {
+	case "actor":
+		return resource[hawapi.Actor, hawapi.CreateActor, hawapi.PatchActor]{c.Actors()}, true
+	case "character":
+		return resource[hawapi.Character, hawapi.CreateCharacter, hawapi.PatchCharacter]{c.Characters()}, true
+	case "episode":
+		return resource[hawapi.Episode, hawapi.CreateEpisode, hawapi.PatchEpisode]{c.Episodes()}, true
+	case "game":
+		return resource[hawapi.Game, hawapi.CreateGame, hawapi.PatchGame]{c.Games()}, true
+	case "location":
+		return resource[hawapi.Location, hawapi.CreateLocation, hawapi.PatchLocation]{c.Locations()}, true
+	case "season":
+		return resource[hawapi.Season, hawapi.CreateSeason, hawapi.PatchSeason]{c.Seasons()}, true
+	case "soundtrack":
+		return resource[hawapi.Soundtrack, hawapi.CreateSoundtrack, hawapi.PatchSoundtrack]{c.Soundtracks()}, true
+	}
+	return nil, false
+}
+
+type command struct {
+	client *hawapi.Client
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	table  bool
+}
+
+func (cmd *command) run(ctx context.Context, name string, args []string) error {
+	switch name {
+	case "info":
+		if err := cmd.expectArgs(name, args, 0); err != nil {
+			return err
+		}
+
+		info, err := cmd.client.InfoContext(ctx)
+		if err != nil {
+			return err
+		}
+		return cmd.print(info)
+	case "overview":
+		if err := cmd.expectArgs(name, args, 0); err != nil {
+			return err
+		}
+
+		overview, err := cmd.client.OverviewContext(ctx)
+		if err != nil {
+			return err
+		}
+		return cmd.print(overview)
+	case "list", "find", "random", "create", "patch", "delete":
+		return cmd.runResource(ctx, name, args)
+	}
+
+	fmt.Fprintf(cmd.stderr, "hawapi: unknown command '%s', run 'hawapi -h' for usage\n", name)
+	return errUsage
+}
+
+func (cmd *command) runResource(ctx context.Context, name string, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintf(cmd.stderr, "hawapi: missing resource for '%s'\n", name)
+		return errUsage
+	}
+
+	res, ok := resources(cmd.client, args[0])
+	if !ok {
+		fmt.Fprintf(cmd.stderr, "hawapi: unknown resource '%s'\n", args[0])
+		return errUsage
+	}
+	args = args[1:]
+
+	switch name {
+	case "list":
+		return cmd.list(ctx, res, args)
+	case "random":
+		if err := cmd.expectArgs(name, args, 0); err != nil {
+			return err
+		}
+
+		item, err := res.random(ctx)
+		if err != nil {
+			return err
+		}
+		return cmd.print(item)
+	case "create":
+		if err := cmd.expectArgs(name, args, 0); err != nil {
+			return err
+		}
+
+		body, err := io.ReadAll(cmd.stdin)
+		if err != nil {
+			return err
+		}
+
+		item, err := res.create(ctx, body)
+		if err != nil {
+			return err
+		}
+		return cmd.print(item)
+	}
+
+	// The remaining commands require an uuid
+	if err := cmd.expectArgs(name, args, 1); err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid uuid '%s'", args[0])
+	}
+
+	switch name {
+	case "find":
+		item, err := res.find(ctx, id)
+		if err != nil {
+			return err
+		}
+		return cmd.print(item)
+	case "patch":
+		body, err := io.ReadAll(cmd.stdin)
+		if err != nil {
+			return err
+		}
+
+		item, err := res.patch(ctx, id, body)
+		if err != nil {
+			return err
+		}
+		return cmd.print(item)
+	default:
+		return res.delete(ctx, id)
+	}
+}
+
+func (cmd *command) list(ctx context.Context, res resourceCommands, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(cmd.stderr)
+
+	page := fs.Int("page", 1, "the page to fetch")
+	size := fs.Int("size", hawapi.DefaultSize, "the size of the page")
+	sort := fs.String("sort", "", "the field used to sort items")
+	order := fs.String("order", "ASC", "the sort order: ASC or DESC")
+
+	filters := make(hawapi.Filters)
+	fs.Func("filter", "a 'key=value' filter, can be repeated", func(s string) error {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok || len(key) == 0 {
+			return fmt.Errorf("expected 'key=value'")
+		}
+
+		filters[key] = value
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+
+	if err := cmd.expectArgs("list", fs.Args(), 0); err != nil {
+		return err
+	}
+
+	list, items, err := res.list(ctx,
+		hawapi.WithFilters(filters),
+		hawapi.WithPage(*page),
+		hawapi.WithSize(*size),
+		hawapi.WithSort(*sort),
+		hawapi.WithOrder(*order),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !cmd.table {
+		return printJSON(cmd.stdout, list)
+	}
+
+	return printTable(cmd.stdout, items)
+}
+
+func (cmd *command) print(v any) error {
+	if cmd.table {
+		return printFields(cmd.stdout, v)
+	}
+	return printJSON(cmd.stdout, v)
+}
+
+func (cmd *command) expectArgs(name string, args []string, n int) error {
+	if len(args) != n {
+		fmt.Fprintf(cmd.stderr, "hawapi: '%s' expects %d argument(s), got %d\n", name, n, len(args))
+		return errUsage
+	}
+	return nil
+}
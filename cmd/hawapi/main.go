@@ -0,0 +1,130 @@
+// Command hawapi is a command-line client for the HawAPI.
+//
+// Usage:
+//
+//	hawapi [flags] <command> [arguments]
+//
+// The commands are:
+//
+//	list <resource> [-page n] [-size n] [-sort field] [-order ASC|DESC] [-filter key=value]
+//	find <resource> <uuid>
+//	random <resource>
+//	create <resource>           reads the JSON item from stdin
+//	patch <resource> <uuid>     reads the JSON merge patch from stdin
+//	delete <resource> <uuid>
+//	info
+//	overview
+//
+// The resources are: actors, characters, episodes, games, locations, seasons and soundtracks.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+)
+
+const usage = `Usage: hawapi [flags] <command> [arguments]
+
+Commands:
+  list <resource> [-page n] [-size n] [-sort field] [-order ASC|DESC] [-filter key=value]
+  find <resource> <uuid>
+  random <resource>
+  create <resource>           reads the JSON item from stdin
+  patch <resource> <uuid>     reads the JSON merge patch from stdin
+  delete <resource> <uuid>
+  info
+  overview
+
+Resources:
+  actors, characters, episodes, games, locations, seasons, soundtracks
+
+Flags:
+`
+
+// errUsage is returned when the command line is invalid, the usage is already printed
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("hawapi", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	endpoint := fs.String("endpoint", hawapi.DefaultEndpoint, "the endpoint of the HawAPI instance")
+	version := fs.String("version", hawapi.DefaultVersion, "the version of the API")
+	language := fs.String("language", hawapi.DefaultLanguage, "the language of items")
+	token := fs.String("token", os.Getenv("HAWAPI_TOKEN"), "the HawAPI token (JWT), defaults to $HAWAPI_TOKEN")
+	cacheDir := fs.String("cache-dir", os.Getenv("HAWAPI_CACHE_DIR"), "the directory caching responses between runs, defaults to $HAWAPI_CACHE_DIR")
+	output := fs.String("output", "json", "the output format: json or table")
+	verbose := fs.Bool("verbose", false, "log requests")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if *output != "json" && *output != "table" {
+		fmt.Fprintf(stderr, "hawapi: unknown output format '%s'\n", *output)
+		return 2
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	logLevel := slog.LevelError
+	if *verbose {
+		logLevel = slog.LevelDebug
+	}
+
+	client := hawapi.NewClientWithOpts(hawapi.Options{
+		Endpoint:   *endpoint,
+		Version:    *version,
+		Language:   *language,
+		Token:      *token,
+		LogHandler: hawapi.NewFormattedHandler(stderr, &slog.HandlerOptions{Level: logLevel}),
+
+		UseInMemoryCache: len(*cacheDir) != 0,
+		CacheDir:         *cacheDir,
+	})
+
+	cmd := &command{
+		client: &client,
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		table:  *output == "table",
+	}
+
+	if err := cmd.run(ctx, fs.Arg(0), fs.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+
+		fmt.Fprintf(stderr, "hawapi: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+	"github.com/HawAPI/go-sdk/hawapi/hawapitest"
+	"github.com/google/uuid"
+)
+
+func Test_run(t *testing.T) {
+	srv := hawapitest.NewServer(hawapitest.Options{Token: "token"})
+	defer srv.Close()
+
+	id := uuid.New()
+	srv.Seed(
+		hawapi.Actor{FirstName: "Lorem", LastName: "Ipsum"},
+		hawapi.Actor{FirstName: "Dolor", LastName: "Sit"},
+		hawapi.Season{Uuid: id, Title: "Amet"},
+	)
+
+	cacheDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		wantCode int
+		wantOut  []string
+	}{
+		{
+			name:     "should list actors as a table",
+			args:     []string{"-output", "table", "list", "actors", "-sort", "first_name"},
+			wantCode: 0,
+			wantOut:  []string{"FIRST_NAME", "Dolor", "Lorem"},
+		},
+		{
+			name:     "should find a season",
+			args:     []string{"find", "season", id.String()},
+			wantCode: 0,
+			wantOut:  []string{`"title": "Amet"`},
+		},
+		{
+			name:     "should find a season using a cache dir",
+			args:     []string{"-cache-dir", cacheDir, "find", "season", id.String()},
+			wantCode: 0,
+			wantOut:  []string{`"title": "Amet"`},
+		},
+		{
+			name:     "should create an item from stdin",
+			args:     []string{"-token", "token", "create", "locations"},
+			stdin:    `{"name": "Hawkins"}`,
+			wantCode: 0,
+			wantOut:  []string{`"name": "Hawkins"`},
+		},
+		{
+			name:     "should show the overview",
+			args:     []string{"-output", "table", "overview"},
+			wantCode: 0,
+			wantOut:  []string{"data_count", "actors=2"},
+		},
+		{
+			name:     "should fail with an api error",
+			args:     []string{"find", "actor", uuid.NewString()},
+			wantCode: 1,
+		},
+		{
+			name:     "should fail with an unknown resource",
+			args:     []string{"list", "users"},
+			wantCode: 2,
+		},
+		{
+			name:     "should fail with an unknown command",
+			args:     []string{"update", "actors"},
+			wantCode: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			args := append([]string{"-endpoint", srv.Endpoint()}, tt.args...)
+			code := run(context.Background(), args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("run() = %v, want %v (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("run() output = %s, want %s", stdout.String(), want)
+				}
+			}
+		})
+	}
+}
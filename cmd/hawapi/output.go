@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// tableSkipFields are not shown as table columns, to keep rows readable
+var tableSkipFields = map[string]bool{
+	"href":       true,
+	"thumbnail":  true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+type field struct {
+	name  string
+	value reflect.Value
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printFields prints a single item as 'FIELD VALUE' rows
+func printFields(w io.Writer, v any) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tVALUE")
+
+	for _, f := range fieldsOf(reflect.ValueOf(v)) {
+		fmt.Fprintf(tw, "%s\t%s\n", f.name, format(f.value))
+	}
+
+	return tw.Flush()
+}
+
+// printTable prints the items as rows, using their scalar fields as columns
+func printTable(w io.Writer, items []any) error {
+	if len(items) == 0 {
+		_, err := fmt.Fprintln(w, "no items found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for i, item := range items {
+		var names, values []string
+		for _, f := range fieldsOf(reflect.ValueOf(item)) {
+			if tableSkipFields[f.name] || !isScalar(f.value) {
+				continue
+			}
+
+			names = append(names, strings.ToUpper(f.name))
+			values = append(values, format(f.value))
+		}
+
+		if i == 0 {
+			fmt.Fprintln(tw, strings.Join(names, "\t"))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// fieldsOf returns the exported fields of a struct, named after their json tag
+func fieldsOf(v reflect.Value) []field {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = sf.Name
+		}
+
+		fields = append(fields, field{name: name, value: v.Field(i)})
+	}
+
+	return fields
+}
+
+func isScalar(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct:
+		// Arrays (e.g. uuid.UUID) are printed using their String method
+		return false
+	}
+	return true
+}
+
+func format(v reflect.Value) string {
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = format(v.Index(i))
+		}
+		return strings.Join(values, ", ")
+	case reflect.Struct:
+		var values []string
+		for _, f := range fieldsOf(v) {
+			values = append(values, f.name+"="+format(f.value))
+		}
+		return "{" + strings.Join(values, " ") + "}"
+	}
+
+	return fmt.Sprint(v.Interface())
+}
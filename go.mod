@@ -1,6 +1,6 @@
 module github.com/HawAPI/go-sdk
 
-go 1.22.0
+go 1.24.0
 
 require (
 	github.com/fatih/color v1.17.0
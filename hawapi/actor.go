@@ -1,6 +1,9 @@
 package hawapi
 
 import (
+	"context"
+	"iter"
+
 	"github.com/google/uuid"
 )
 
@@ -50,99 +53,86 @@ type CreateActor struct {
 	Sources     []string `json:"sources,omitempty"`
 }
 
-type PatchActor = CreateActor
-
-type ActorResponse struct {
-	BaseResponse
-	Data Actor `json:"data"`
+// PatchActor is a JSON Merge Patch, only its set fields are updated
+type PatchActor struct {
+	FirstName   Optional[string]   `json:"first_name,omitzero"`
+	LastName    Optional[string]   `json:"last_name,omitzero"`
+	Nicknames   Optional[[]string] `json:"nicknames,omitzero"`
+	Socials     Optional[[]Social] `json:"socials,omitzero"`
+	Nationality Optional[string]   `json:"nationality,omitzero"`
+	BirthDate   Optional[string]   `json:"birth_date,omitzero"`
+	DeathDate   Optional[string]   `json:"death_date,omitzero"`
+	Gender      Optional[int]      `json:"gender,omitzero"`
+	Seasons     Optional[[]string] `json:"seasons,omitzero"`
+	Awards      Optional[[]string] `json:"awards,omitzero"`
+	Character   Optional[string]   `json:"character,omitzero"`
+	Thumbnail   Optional[string]   `json:"thumbnail,omitzero"`
+	Images      Optional[[]string] `json:"images,omitzero"`
+	Sources     Optional[[]string] `json:"sources,omitzero"`
 }
 
-type ActorListResponse struct {
-	BaseResponse
-	Data []Actor `json:"data"`
+type ActorResponse = ItemResponse[Actor]
+
+type ActorListResponse = ListResponse[Actor]
+
+// Actors returns the typed client for actors
+func (c *Client) Actors() Resource[Actor, CreateActor, PatchActor] {
+	return NewResource[Actor, CreateActor, PatchActor](c, actorOrigin)
 }
 
 // ListActors will get all actors
 func (c *Client) ListActors(options ...QueryOptions) (ActorListResponse, error) {
-	var actors []Actor
-	var res ActorListResponse
-
-	doRes, err := c.doGetRequest(actorOrigin, options, &actors)
-	if err != nil {
-		return res, err
-	}
+	return c.Actors().List(context.Background(), options...)
+}
 
-	res = ActorListResponse{
-		BaseResponse: doRes,
-		Data:         actors,
-	}
+// ListActorsContext will get all actors using the given context
+func (c *Client) ListActorsContext(ctx context.Context, options ...QueryOptions) (ActorListResponse, error) {
+	return c.Actors().List(ctx, options...)
+}
 
-	return res, nil
+// AllActors iterates over all actors of every page, starting at the page defined by the options
+func (c *Client) AllActors(ctx context.Context, options ...QueryOptions) iter.Seq2[Actor, error] {
+	return c.Actors().All(ctx, options...)
 }
 
 // FindActor will get a single item by uuid
 func (c *Client) FindActor(id uuid.UUID) (ActorResponse, error) {
-	var actor Actor
-	var res ActorResponse
-
-	doRes, err := c.doGetRequest(actorOrigin+"/"+id.String(), nil, &actor)
-	if err != nil {
-		return res, err
-	}
-
-	res = ActorResponse{
-		BaseResponse: doRes,
-		Data:         actor,
-	}
+	return c.Actors().Find(context.Background(), id)
+}
 
-	return res, nil
+// FindActorContext will get a single item by uuid using the given context
+func (c *Client) FindActorContext(ctx context.Context, id uuid.UUID) (ActorResponse, error) {
+	return c.Actors().Find(ctx, id)
 }
 
 func (c *Client) RandomActor() (ActorResponse, error) {
-	var actor Actor
-	var res ActorResponse
-
-	doRes, err := c.doGetRequest(actorOrigin+"/random", nil, &actor)
-	if err != nil {
-		return res, err
-	}
-
-	res = ActorResponse{
-		BaseResponse: doRes,
-		Data:         actor,
-	}
+	return c.Actors().Random(context.Background())
+}
 
-	return res, nil
+func (c *Client) RandomActorContext(ctx context.Context) (ActorResponse, error) {
+	return c.Actors().Random(ctx)
 }
 
 func (c *Client) CreateActor(s CreateActor) (Actor, error) {
-	var actor Actor
-
-	err := c.doPostRequest(actorOrigin, s, &actor)
-	if err != nil {
-		return actor, err
-	}
-
-	return actor, nil
+	return c.Actors().Create(context.Background(), s)
 }
 
-func (c *Client) PatchActor(id uuid.UUID, p PatchActor) (Actor, error) {
-	var actor Actor
+func (c *Client) CreateActorContext(ctx context.Context, s CreateActor) (Actor, error) {
+	return c.Actors().Create(ctx, s)
+}
 
-	err := c.doPatchRequest(actorOrigin+"/"+id.String(), &p)
-	if err != nil {
-		return actor, err
-	}
+func (c *Client) PatchActor(id uuid.UUID, p PatchActor, options ...WriteOptions) (Actor, error) {
+	return c.Actors().Patch(context.Background(), id, p, options...)
+}
 
-	res, err := c.FindActor(id)
-	if err != nil {
-		return actor, err
-	}
+func (c *Client) PatchActorContext(ctx context.Context, id uuid.UUID, p PatchActor, options ...WriteOptions) (Actor, error) {
+	return c.Actors().Patch(ctx, id, p, options...)
+}
 
-	actor = res.Data
-	return actor, nil
+func (c *Client) DeleteActor(id uuid.UUID, options ...WriteOptions) error {
+	return c.Actors().Delete(context.Background(), id, options...)
 }
 
-func (c *Client) DeleteActor(id uuid.UUID) error {
-	return c.doDeleteRequest(actorOrigin + "/" + id.String())
+func (c *Client) DeleteActorContext(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return c.Actors().Delete(ctx, id, options...)
 }
@@ -0,0 +1,83 @@
+package hawapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const authOrigin = "auth"
+
+type RegisterRequest struct {
+	Username  string `json:"username"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+}
+
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type User struct {
+	Uuid      uuid.UUID `json:"uuid"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"first_name,omitempty"`
+	LastName  string    `json:"last_name,omitempty"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+}
+
+// AuthResponse is the registered or authenticated user, with its token (JWT)
+type AuthResponse struct {
+	User
+	Token     string `json:"token"`
+	TokenType string `json:"token_type,omitempty"`
+}
+
+// Register will create a new user
+//
+// The returned token is used by the client if Options.AdoptAuthToken is enabled
+func (c *Client) Register(ctx context.Context, register RegisterRequest) (AuthResponse, error) {
+	return c.doAuthRequest(ctx, "register", register, http.StatusCreated)
+}
+
+// Authenticate will get a new token for the user
+//
+// The returned token is used by the client if Options.AdoptAuthToken is enabled
+func (c *Client) Authenticate(ctx context.Context, credentials Credentials) (AuthResponse, error) {
+	return c.doAuthRequest(ctx, "authenticate", credentials, http.StatusOK)
+}
+
+func (c *Client) doAuthRequest(ctx context.Context, path string, in any, wantStatus int) (AuthResponse, error) {
+	var res AuthResponse
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return res, err
+	}
+
+	url := c.buildUrl(authOrigin+"/"+path, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return res, err
+	}
+
+	_, err = c.doRequest(req, wantStatus, &res)
+	if err != nil {
+		return res, err
+	}
+
+	if c.options.AdoptAuthToken && len(res.Token) != 0 {
+		c.adoptToken(res.Token)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,79 @@
+package hawapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newBenchmarkClient creates a client caching a page of 100 actors
+func newBenchmarkClient(b *testing.B) *Client {
+	actors := make([]Actor, 100)
+	for i := range actors {
+		actors[i] = Actor{
+			FirstName: fmt.Sprintf("Lorem %d", i),
+			LastName:  "Ipsum",
+			Nicknames: []string{"Dolor", "Sit"},
+			Socials:   []Social{{Social: "Twitter", Handle: "@lorem", URL: "https://twitter.com/lorem"}},
+			Seasons:   []string{"/api/v1/seasons/1", "/api/v1/seasons/2"},
+			Images:    []string{"https://cdn.theproject.id/hawapi/image.jpg"},
+			CreatedAt: "2023-01-01T00:00:00Z",
+			UpdatedAt: "2023-01-01T00:00:00Z",
+		}
+	}
+
+	body, err := json.Marshal(actors)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write(body)
+	}))
+	b.Cleanup(server.Close)
+
+	c := NewClientWithOpts(Options{
+		Endpoint:         server.URL,
+		LogHandler:       defaultTestLoggerHandler,
+		UseInMemoryCache: true,
+	})
+	return &c
+}
+
+func BenchmarkClient_doGetRequest(b *testing.B) {
+	ctx := context.Background()
+
+	b.Run("hit", func(b *testing.B) {
+		c := newBenchmarkClient(b)
+		if _, err := c.doGetRequest(ctx, actorOrigin, nil, &[]Actor{}); err != nil {
+			b.Fatal(err)
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var actors []Actor
+			if _, err := c.doGetRequest(ctx, actorOrigin, nil, &actors); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("miss", func(b *testing.B) {
+		c := newBenchmarkClient(b)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.ClearCache()
+
+			var actors []Actor
+			if _, err := c.doGetRequest(ctx, actorOrigin, nil, &actors); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package hawapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// encodedResponse is a cached response stored by a disk cache
+type encodedResponse struct {
+	BaseResponse
+	Origin   string          `json:"origin"`
+	Data     json.RawMessage `json:"data"`
+	StoredAt time.Time       `json:"stored_at"`
+}
+
+// cachedResponseCodec converts cached responses to bytes, used by the disk cache (see Options.CacheDir)
+type cachedResponseCodec struct{}
+
+func (cachedResponseCodec) Encode(value any) ([]byte, error) {
+	cbr, ok := value.(cachedBaseResponse)
+	if !ok {
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+
+	return json.Marshal(encodedResponse{
+		BaseResponse: cbr.BaseResponse,
+		Origin:       cbr.origin,
+		Data:         cbr.data,
+		StoredAt:     cbr.storedAt,
+	})
+}
+
+func (cachedResponseCodec) Decode(data []byte) (any, error) {
+	var er encodedResponse
+	if err := json.Unmarshal(data, &er); err != nil {
+		return nil, err
+	}
+
+	return cachedBaseResponse{
+		BaseResponse: er.BaseResponse,
+		origin:       er.Origin,
+		data:         er.Data,
+		storedAt:     er.StoredAt,
+	}, nil
+}
+
+// cacheKeyPrefix returns the prefix of all cache keys (see buildUrl)
+func (c *Client) cacheKeyPrefix() string {
+	return fmt.Sprintf("%s/%s/", c.options.Endpoint, c.options.Version)
+}
+
+// originOf returns the resource origin (e.g. 'actors') of a cache key, if it starts with prefix
+func originOf(prefix, key string) (string, bool) {
+	path, ok := strings.CutPrefix(key, prefix)
+	if !ok {
+		return "", false
+	}
+
+	path, _, _ = strings.Cut(path, "?")
+	origin, _, _ := strings.Cut(path, "/")
+	return origin, len(origin) != 0
+}
@@ -0,0 +1,140 @@
+package hawapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClient_CacheDir(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.Header().Set(apiHeaderEtag, `"v1"`)
+		w.Write([]byte(`{"first_name": "Lorem", "last_name": "Ipsum"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	newClient := func() Client {
+		return NewClientWithOpts(Options{
+			Endpoint:         server.URL,
+			LogHandler:       defaultTestLoggerHandler,
+			UseInMemoryCache: true,
+			CacheMaxAge:      time.Hour,
+			CacheDir:         dir,
+		})
+	}
+
+	ctx := context.Background()
+	origin := "actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a"
+
+	var first Actor
+	c := newClient()
+	if _, err := c.doGetRequest(ctx, origin, nil, &first); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("cache dir has %d entries, want 1", len(entries))
+	}
+
+	// A new client (e.g. the next CLI run) is served by the cached response
+	c = newClient()
+
+	var second Actor
+	res, err := c.doGetRequest(ctx, origin, nil, &second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+
+	if !res.Cached || res.Etag != `"v1"` {
+		t.Errorf("doGetRequest() = %+v, want a cached response with etag", res)
+	}
+
+	if second.FirstName != first.FirstName || second.LastName != first.LastName {
+		t.Errorf("doGetRequest() actor = %+v, want %+v", second, first)
+	}
+
+	if got := c.CacheStats().Origins["actors"].Hits; got != 1 {
+		t.Errorf("CacheStats() hits = %d, want 1", got)
+	}
+}
+
+func TestClient_CacheDir_invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`[{"first_name": "Lorem"}]`))
+	}))
+	defer server.Close()
+
+	// A file can't be used as cache dir, the in-memory cache is used instead
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClientWithOpts(Options{
+		Endpoint:         server.URL,
+		LogHandler:       defaultTestLoggerHandler,
+		UseInMemoryCache: true,
+		CacheDir:         file,
+	})
+
+	if _, err := c.doGetRequest(context.Background(), "actors", nil, &[]Actor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.CacheSize(); got != 1 {
+		t.Errorf("CacheSize() = %d, want 1", got)
+	}
+}
+
+func TestClient_CacheDir_evictions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"first_name": "Lorem", "last_name": "Ipsum"}`))
+	}))
+	defer server.Close()
+
+	// Each entry file fits, but not two
+	c := NewClientWithOpts(Options{
+		Endpoint:         server.URL,
+		LogHandler:       defaultTestLoggerHandler,
+		UseInMemoryCache: true,
+		CacheDir:         t.TempDir(),
+		CacheMaxBytes:    600,
+	})
+
+	ctx := context.Background()
+	for _, origin := range []string{"actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a", "characters/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a"} {
+		if _, err := c.doGetRequest(ctx, origin, nil, &Actor{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats := c.CacheStats()
+	if got := stats.Origins["actors"].Evictions; got != 1 {
+		t.Errorf("CacheStats() actors evictions = %d, want 1", got)
+	}
+
+	if got := stats.Evictions; got != 1 {
+		t.Errorf("CacheStats() evictions = %d, want 1", got)
+	}
+
+	// Sizes are read from the index of the disk cache
+	if stats.Origins["actors"].Bytes != 0 || stats.Origins["characters"].Bytes == 0 {
+		t.Errorf("CacheStats() origins = %+v", stats.Origins)
+	}
+}
@@ -0,0 +1,90 @@
+package hawapi
+
+import (
+	"strings"
+	"time"
+
+	"github.com/HawAPI/go-sdk/pkg/cache"
+)
+
+// Endpoint is a class of GET endpoints sharing the same CachePolicy
+type Endpoint int
+
+const (
+	// EndpointList is a page of items, e.g. 'actors?page=2'
+	EndpointList Endpoint = iota
+
+	// EndpointItem is a single item, e.g. 'actors/<uuid>'
+	EndpointItem
+
+	// EndpointRandom is a random item, e.g. 'actors/random'
+	EndpointRandom
+
+	// EndpointOverview is the API overview
+	EndpointOverview
+)
+
+func (e Endpoint) String() string {
+	switch e {
+	case EndpointList:
+		return "list"
+	case EndpointItem:
+		return "item"
+	case EndpointRandom:
+		return "random"
+	case EndpointOverview:
+		return "overview"
+	}
+	return "unknown"
+}
+
+// CachePolicy defines if and how long the responses of an Endpoint are cached
+type CachePolicy struct {
+	// Define if responses are cached
+	Enabled bool
+
+	// How long a response is kept, only supported by cache.TTLCache backends
+	//
+	// Default value: CacheTTL
+	TTL time.Duration
+}
+
+// DefaultCachePolicies caches all responses, except random items
+var DefaultCachePolicies = map[Endpoint]CachePolicy{
+	EndpointList:     {Enabled: true},
+	EndpointItem:     {Enabled: true},
+	EndpointRandom:   {Enabled: false},
+	EndpointOverview: {Enabled: true},
+}
+
+// endpointOf returns the Endpoint of an origin (e.g. 'actors/random')
+func endpointOf(origin string) Endpoint {
+	switch {
+	case origin == "overview":
+		return EndpointOverview
+	case strings.HasSuffix(origin, "/random"):
+		return EndpointRandom
+	case strings.Contains(origin, "/"):
+		return EndpointItem
+	}
+	return EndpointList
+}
+
+// cachePolicy returns the CachePolicy of an origin
+func (c *Client) cachePolicy(origin string) CachePolicy {
+	endpoint := endpointOf(origin)
+	if policy, ok := c.options.CachePolicies[endpoint]; ok {
+		return policy
+	}
+	return DefaultCachePolicies[endpoint]
+}
+
+// setCached stores the response, using the TTL of the policy if defined
+func (c *Client) setCached(url string, policy CachePolicy, cbr cachedBaseResponse) {
+	if ttlCache, ok := c.cache.(cache.TTLCache); ok && policy.TTL > 0 {
+		ttlCache.SetWithTTL(url, cbr, policy.TTL)
+		return
+	}
+
+	c.cache.Set(url, cbr)
+}
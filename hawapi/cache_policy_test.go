@@ -0,0 +1,114 @@
+package hawapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HawAPI/go-sdk/pkg/cache"
+)
+
+func Test_endpointOf(t *testing.T) {
+	tests := []struct {
+		origin string
+		want   Endpoint
+	}{
+		{origin: "actors", want: EndpointList},
+		{origin: "actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a", want: EndpointItem},
+		{origin: "actors/random", want: EndpointRandom},
+		{origin: "overview", want: EndpointOverview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			if got := endpointOf(tt.origin); got != tt.want {
+				t.Errorf("endpointOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// ttlRecordingCache records the TTL of the last SetWithTTL call
+type ttlRecordingCache struct {
+	cache.TTLCache
+	ttl time.Duration
+}
+
+func (c *ttlRecordingCache) SetWithTTL(key string, value any, ttl time.Duration) {
+	c.ttl = ttl
+	c.TTLCache.SetWithTTL(key, value, ttl)
+}
+
+func TestClient_doGetRequest_cachePolicies(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.Write([]byte(`{"first_name": "Lorem", "last_name": "Ipsum"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		origin       string
+		policies     map[Endpoint]CachePolicy
+		wantRequests int
+		wantTTL      time.Duration
+	}{
+		{
+			name:         "should cache items by default",
+			origin:       "actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a",
+			wantRequests: 1,
+		},
+		{
+			name:         "should not cache random items by default",
+			origin:       "actors/random",
+			wantRequests: 2,
+		},
+		{
+			name:         "should cache random items if enabled",
+			origin:       "actors/random",
+			policies:     map[Endpoint]CachePolicy{EndpointRandom: {Enabled: true, TTL: time.Second}},
+			wantRequests: 1,
+			wantTTL:      time.Second,
+		},
+		{
+			name:         "should not cache overview if disabled",
+			origin:       "overview",
+			policies:     map[Endpoint]CachePolicy{EndpointOverview: {Enabled: false}},
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			ttlCache := &ttlRecordingCache{TTLCache: cache.NewLRUCache(cache.Options{})}
+
+			c := NewClientWithOpts(Options{
+				Endpoint:         server.URL,
+				LogHandler:       defaultTestLoggerHandler,
+				UseInMemoryCache: true,
+				Cache:            ttlCache,
+				CachePolicies:    tt.policies,
+			})
+
+			for i := 0; i < 2; i++ {
+				if _, err := c.doGetRequest(context.Background(), tt.origin, nil, &Actor{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			if ttlCache.ttl != tt.wantTTL {
+				t.Errorf("ttl = %v, want %v", ttlCache.ttl, tt.wantTTL)
+			}
+		})
+	}
+
+	if !DefaultCachePolicies[EndpointOverview].Enabled {
+		t.Errorf("DefaultCachePolicies should not be modified")
+	}
+}
@@ -0,0 +1,175 @@
+package hawapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/HawAPI/go-sdk/pkg/cache"
+)
+
+// CacheCounters are the cache statistics of a resource origin (e.g. 'actors'), or of all origins
+type CacheCounters struct {
+	// Responses served from the cache
+	Hits int64 `json:"hits"`
+
+	// Responses fetched from the API, because they were not cached or stale
+	Misses int64 `json:"misses"`
+
+	// Responses evicted because the cache was full or they expired
+	//
+	// Only supported by the default cache backend
+	Evictions int64 `json:"evictions"`
+
+	// Stale responses revalidated by the API ('304 Not Modified')
+	Revalidations int64 `json:"revalidations"`
+
+	// Stale responses served because the API failed (see Options.CacheServeStaleOnError)
+	StaleServes int64 `json:"stale_serves"`
+
+	// The size of the cached response bodies, or of their files when using CacheDir
+	//
+	// Only supported by cache.SizeCache and cache.RangeCache backends
+	Bytes int64 `json:"bytes"`
+}
+
+func (c *CacheCounters) add(other CacheCounters) {
+	c.Hits += other.Hits
+	c.Misses += other.Misses
+	c.Evictions += other.Evictions
+	c.Revalidations += other.Revalidations
+	c.StaleServes += other.StaleServes
+	c.Bytes += other.Bytes
+}
+
+// CacheStats are the cache statistics of all origins, and of each origin
+type CacheStats struct {
+	CacheCounters
+	Origins map[string]CacheCounters `json:"origins"`
+}
+
+type cacheEvent int
+
+const (
+	cacheHit cacheEvent = iota
+	cacheMiss
+	cacheEviction
+	cacheRevalidation
+	cacheStaleServe
+)
+
+// cacheStats counts the cache events of each origin, shared by all requests
+type cacheStats struct {
+	mu      sync.Mutex
+	origins map[string]CacheCounters
+}
+
+func newCacheStats() *cacheStats {
+	return &cacheStats{origins: make(map[string]CacheCounters)}
+}
+
+// record counts an event of the origin
+func (s *cacheStats) record(origin string, event cacheEvent) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counters := s.origins[origin]
+	switch event {
+	case cacheHit:
+		counters.Hits++
+	case cacheMiss:
+		counters.Misses++
+	case cacheEviction:
+		counters.Evictions++
+	case cacheRevalidation:
+		counters.Revalidations++
+	case cacheStaleServe:
+		counters.StaleServes++
+	}
+	s.origins[origin] = counters
+}
+
+// snapshot copies the counters of each origin
+func (s *cacheStats) snapshot() map[string]CacheCounters {
+	if s == nil {
+		return make(map[string]CacheCounters)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.origins)
+}
+
+// CacheStats returns the cache statistics since the client was created
+func (c *Client) CacheStats() CacheStats {
+	stats := CacheStats{Origins: c.stats.snapshot()}
+
+	// Sizes are read from the index of the backend when possible, instead of reading every value
+	if sc, ok := c.cache.(cache.SizeCache); ok {
+		prefix := c.cacheKeyPrefix()
+		sc.RangeSizes(func(key string, size int64) bool {
+			if origin, ok := originOf(prefix, key); ok {
+				counters := stats.Origins[origin]
+				counters.Bytes += size
+				stats.Origins[origin] = counters
+			}
+			return true
+		})
+	} else if rc, ok := c.cache.(cache.RangeCache); ok {
+		rc.Range(func(_ string, value any) bool {
+			if cbr, ok := value.(cachedBaseResponse); ok {
+				counters := stats.Origins[cbr.origin]
+				counters.Bytes += int64(len(cbr.data))
+				stats.Origins[cbr.origin] = counters
+			}
+			return true
+		})
+	}
+
+	for _, counters := range stats.Origins {
+		stats.add(counters)
+	}
+
+	return stats
+}
+
+// LogCacheStats logs the cache statistics every interval, until the context is done
+//
+//	go client.LogCacheStats(ctx, time.Minute)
+//
+// It returns immediately if the interval is not positive
+func (c *Client) LogCacheStats(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		c.logger.Warn(fmt.Sprintf("cache stats are not logged, the interval %s is not positive", interval))
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := c.CacheStats()
+			c.logger.Info("cache stats",
+				slog.Int64("hits", stats.Hits),
+				slog.Int64("misses", stats.Misses),
+				slog.Int64("evictions", stats.Evictions),
+				slog.Int64("revalidations", stats.Revalidations),
+				slog.Int64("stale_serves", stats.StaleServes),
+				slog.Int64("bytes", stats.Bytes),
+				slog.Any("origins", stats.Origins),
+			)
+		}
+	}
+}
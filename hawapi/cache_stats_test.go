@@ -0,0 +1,151 @@
+package hawapi
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_CacheStats(t *testing.T) {
+	const body = `{"first_name": "Lorem", "last_name": "Ipsum"}`
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(apiHeaderEtag, `"v1"`)
+
+		switch {
+		case status != http.StatusOK:
+			w.WriteHeader(status)
+		case req.Header.Get(headerIfNoneMatch) == `"v1"`:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Write([]byte(body))
+		}
+	}))
+	defer server.Close()
+
+	c := NewClientWithOpts(Options{
+		Endpoint:               server.URL,
+		LogHandler:             defaultTestLoggerHandler,
+		UseInMemoryCache:       true,
+		CacheMaxAge:            time.Hour,
+		CacheServeStaleOnError: true,
+		Retry:                  RetryPolicy{MaxAttempts: 1},
+	})
+
+	ctx := context.Background()
+	get := func(origin string) {
+		t.Helper()
+		if _, err := c.doGetRequest(ctx, origin, nil, &Actor{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	get("actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a")
+	get("actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a")
+	get("seasons")
+
+	// Stale responses are revalidated, or served when the API fails
+	c.options.CacheMaxAge = time.Nanosecond
+	get("actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a")
+
+	status = http.StatusServiceUnavailable
+	get("actors/2cd8d1a6-6f1f-4e7b-8a4c-2d7e1d0c8e2a")
+
+	got := c.CacheStats()
+
+	wantActors := CacheCounters{Hits: 1, Misses: 1, Revalidations: 1, StaleServes: 1, Bytes: int64(len(body))}
+	if got.Origins["actors"] != wantActors {
+		t.Errorf("CacheStats() actors = %+v, want %+v", got.Origins["actors"], wantActors)
+	}
+
+	wantTotal := CacheCounters{Hits: 1, Misses: 2, Revalidations: 1, StaleServes: 1, Bytes: int64(2 * len(body))}
+	if got.CacheCounters != wantTotal {
+		t.Errorf("CacheStats() = %+v, want %+v", got.CacheCounters, wantTotal)
+	}
+
+	// Without stale response, the error is returned
+	if _, err := c.doGetRequest(ctx, "episodes", nil, &Actor{}); err == nil {
+		t.Error("doGetRequest() should fail")
+	}
+}
+
+func TestClient_CacheStats_evictions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c := NewClientWithOpts(Options{
+		Endpoint:         server.URL,
+		LogHandler:       defaultTestLoggerHandler,
+		UseInMemoryCache: true,
+		CacheTTL:         time.Millisecond,
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.ListActors(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	got := c.CacheStats().Origins["actors"]
+	if got.Evictions != 1 || got.Misses != 2 {
+		t.Errorf("CacheStats() actors = %+v, want 1 eviction and 2 misses", got)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.String()
+}
+
+func TestClient_LogCacheStats(t *testing.T) {
+	var out syncBuffer
+	c := NewClientWithOpts(Options{
+		LogHandler: slog.NewJSONHandler(&out, nil),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c.LogCacheStats(ctx, 10*time.Millisecond)
+
+	if !strings.Contains(out.String(), `"msg":"cache stats"`) {
+		t.Errorf("LogCacheStats() output = %s", out.String())
+	}
+}
+
+func TestClient_LogCacheStats_invalidInterval(t *testing.T) {
+	var out syncBuffer
+	c := NewClientWithOpts(Options{
+		LogHandler: slog.NewJSONHandler(&out, nil),
+	})
+
+	// Returns instead of panicking
+	c.LogCacheStats(context.Background(), 0)
+
+	if strings.Contains(out.String(), `"msg":"cache stats"`) {
+		t.Errorf("LogCacheStats() output = %s", out.String())
+	}
+}
@@ -1,6 +1,9 @@
 package hawapi
 
 import (
+	"context"
+	"iter"
+
 	"github.com/google/uuid"
 )
 
@@ -36,99 +39,82 @@ type CreateCharacter struct {
 	Sources   []string `json:"sources,omitempty"`
 }
 
-type PatchCharacter = CreateCharacter
-
-type CharacterResponse struct {
-	BaseResponse
-	Data Character `json:"data"`
+// PatchCharacter is a JSON Merge Patch, only its set fields are updated
+type PatchCharacter struct {
+	FirstName Optional[string]   `json:"first_name,omitzero"`
+	LastName  Optional[string]   `json:"last_name,omitzero"`
+	Nicknames Optional[[]string] `json:"nicknames,omitzero"`
+	Gender    Optional[int]      `json:"gender,omitzero"`
+	Actor     Optional[string]   `json:"actor,omitzero"`
+	BirthDate Optional[string]   `json:"birth_date,omitzero"`
+	DeathDate Optional[string]   `json:"death_date,omitzero"`
+	Thumbnail Optional[string]   `json:"thumbnail,omitzero"`
+	Images    Optional[[]string] `json:"images,omitzero"`
+	Sources   Optional[[]string] `json:"sources,omitzero"`
 }
 
-type CharacterListResponse struct {
-	BaseResponse
-	Data []Character `json:"data"`
+type CharacterResponse = ItemResponse[Character]
+
+type CharacterListResponse = ListResponse[Character]
+
+// Characters returns the typed client for characters
+func (c *Client) Characters() Resource[Character, CreateCharacter, PatchCharacter] {
+	return NewResource[Character, CreateCharacter, PatchCharacter](c, characterOrigin)
 }
 
 // ListCharacters will get all characters
 func (c *Client) ListCharacters(options ...QueryOptions) (CharacterListResponse, error) {
-	var characters []Character
-	var res CharacterListResponse
-
-	doRes, err := c.doGetRequest(characterOrigin, options, &characters)
-	if err != nil {
-		return res, err
-	}
+	return c.Characters().List(context.Background(), options...)
+}
 
-	res = CharacterListResponse{
-		BaseResponse: doRes,
-		Data:         characters,
-	}
+// ListCharactersContext will get all characters using the given context
+func (c *Client) ListCharactersContext(ctx context.Context, options ...QueryOptions) (CharacterListResponse, error) {
+	return c.Characters().List(ctx, options...)
+}
 
-	return res, nil
+// AllCharacters iterates over all characters of every page, starting at the page defined by the options
+func (c *Client) AllCharacters(ctx context.Context, options ...QueryOptions) iter.Seq2[Character, error] {
+	return c.Characters().All(ctx, options...)
 }
 
 // FindCharacter will get a single item by uuid
 func (c *Client) FindCharacter(id uuid.UUID) (CharacterResponse, error) {
-	var character Character
-	var res CharacterResponse
-
-	doRes, err := c.doGetRequest(characterOrigin+"/"+id.String(), nil, &character)
-	if err != nil {
-		return res, err
-	}
-
-	res = CharacterResponse{
-		BaseResponse: doRes,
-		Data:         character,
-	}
+	return c.Characters().Find(context.Background(), id)
+}
 
-	return res, nil
+// FindCharacterContext will get a single item by uuid using the given context
+func (c *Client) FindCharacterContext(ctx context.Context, id uuid.UUID) (CharacterResponse, error) {
+	return c.Characters().Find(ctx, id)
 }
 
 func (c *Client) RandomCharacter() (CharacterResponse, error) {
-	var character Character
-	var res CharacterResponse
-
-	doRes, err := c.doGetRequest(characterOrigin+"/random", nil, &character)
-	if err != nil {
-		return res, err
-	}
-
-	res = CharacterResponse{
-		BaseResponse: doRes,
-		Data:         character,
-	}
+	return c.Characters().Random(context.Background())
+}
 
-	return res, nil
+func (c *Client) RandomCharacterContext(ctx context.Context) (CharacterResponse, error) {
+	return c.Characters().Random(ctx)
 }
 
 func (c *Client) CreateCharacter(s CreateCharacter) (Character, error) {
-	var character Character
-
-	err := c.doPostRequest(characterOrigin, s, &character)
-	if err != nil {
-		return character, err
-	}
-
-	return character, nil
+	return c.Characters().Create(context.Background(), s)
 }
 
-func (c *Client) PatchCharacter(id uuid.UUID, p PatchCharacter) (Character, error) {
-	var character Character
+func (c *Client) CreateCharacterContext(ctx context.Context, s CreateCharacter) (Character, error) {
+	return c.Characters().Create(ctx, s)
+}
 
-	err := c.doPatchRequest(characterOrigin+"/"+id.String(), &p)
-	if err != nil {
-		return character, err
-	}
+func (c *Client) PatchCharacter(id uuid.UUID, p PatchCharacter, options ...WriteOptions) (Character, error) {
+	return c.Characters().Patch(context.Background(), id, p, options...)
+}
 
-	res, err := c.FindCharacter(id)
-	if err != nil {
-		return character, err
-	}
+func (c *Client) PatchCharacterContext(ctx context.Context, id uuid.UUID, p PatchCharacter, options ...WriteOptions) (Character, error) {
+	return c.Characters().Patch(ctx, id, p, options...)
+}
 
-	character = res.Data
-	return character, nil
+func (c *Client) DeleteCharacter(id uuid.UUID, options ...WriteOptions) error {
+	return c.Characters().Delete(context.Background(), id, options...)
 }
 
-func (c *Client) DeleteCharacter(id uuid.UUID) error {
-	return c.doDeleteRequest(characterOrigin + "/" + id.String())
+func (c *Client) DeleteCharacterContext(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return c.Characters().Delete(ctx, id, options...)
 }
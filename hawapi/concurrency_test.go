@@ -0,0 +1,54 @@
+package hawapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// TestClient_concurrent must be run with '-race' to be meaningful
+func TestClient_concurrent(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(apiHeaderRateLimitRemaining, "100")
+		w.Header().Set(apiHeaderPageIndex, r.URL.Query().Get("page"))
+		w.Write([]byte(`[{"first_name": "Lorem", "last_name": "Ipsum"}]`))
+	}))
+	defer sv.Close()
+
+	c := NewClientWithOpts(Options{
+		Endpoint:         sv.URL,
+		LogHandler:       defaultTestLoggerHandler,
+		UseInMemoryCache: true,
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				res, err := c.ListActorsContext(context.Background(), WithPage(i%5+1))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if len(res.Data) != 1 {
+					t.Error(fmt.Errorf("ListActors() items = %v, want %v", len(res.Data), 1))
+					return
+				}
+
+				c.Quota()
+				c.CacheSize()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := c.CacheSize(); got != 5 {
+		t.Errorf("CacheSize() = %v, want %v", got, 5)
+	}
+}
@@ -1,6 +1,9 @@
 package hawapi
 
 import (
+	"context"
+	"iter"
+
 	"github.com/google/uuid"
 )
 
@@ -38,99 +41,83 @@ type CreateEpisode struct {
 	Sources     []string `json:"sources,omitempty"`
 }
 
-type PatchEpisode = CreateEpisode
-
-type EpisodeResponse struct {
-	BaseResponse
-	Data Episode `json:"data"`
+// PatchEpisode is a JSON Merge Patch, only its set fields are updated
+type PatchEpisode struct {
+	Title       Optional[string]   `json:"title,omitzero"`
+	Description Optional[string]   `json:"description,omitzero"`
+	Language    Optional[string]   `json:"language,omitzero"`
+	Duration    Optional[int64]    `json:"duration,omitzero"`
+	Season      Optional[string]   `json:"season,omitzero"`
+	EpisodeNum  Optional[byte]     `json:"episode_num,omitzero"`
+	NextEpisode Optional[string]   `json:"next_episode,omitzero"`
+	PrevEpisode Optional[string]   `json:"prev_episode,omitzero"`
+	Thumbnail   Optional[string]   `json:"thumbnail,omitzero"`
+	Images      Optional[[]string] `json:"images,omitzero"`
+	Sources     Optional[[]string] `json:"sources,omitzero"`
 }
 
-type EpisodeListResponse struct {
-	BaseResponse
-	Data []Episode `json:"data"`
+type EpisodeResponse = ItemResponse[Episode]
+
+type EpisodeListResponse = ListResponse[Episode]
+
+// Episodes returns the typed client for episodes
+func (c *Client) Episodes() Resource[Episode, CreateEpisode, PatchEpisode] {
+	return NewResource[Episode, CreateEpisode, PatchEpisode](c, episodeOrigin)
 }
 
 // ListEpisodes will get all episodes
 func (c *Client) ListEpisodes(options ...QueryOptions) (EpisodeListResponse, error) {
-	var episodes []Episode
-	var res EpisodeListResponse
-
-	doRes, err := c.doGetRequest(episodeOrigin, options, &episodes)
-	if err != nil {
-		return res, err
-	}
+	return c.Episodes().List(context.Background(), options...)
+}
 
-	res = EpisodeListResponse{
-		BaseResponse: doRes,
-		Data:         episodes,
-	}
+// ListEpisodesContext will get all episodes using the given context
+func (c *Client) ListEpisodesContext(ctx context.Context, options ...QueryOptions) (EpisodeListResponse, error) {
+	return c.Episodes().List(ctx, options...)
+}
 
-	return res, nil
+// AllEpisodes iterates over all episodes of every page, starting at the page defined by the options
+func (c *Client) AllEpisodes(ctx context.Context, options ...QueryOptions) iter.Seq2[Episode, error] {
+	return c.Episodes().All(ctx, options...)
 }
 
 // FindEpisode will get a single item by uuid
 func (c *Client) FindEpisode(id uuid.UUID) (EpisodeResponse, error) {
-	var episode Episode
-	var res EpisodeResponse
-
-	doRes, err := c.doGetRequest(episodeOrigin+"/"+id.String(), nil, &episode)
-	if err != nil {
-		return res, err
-	}
-
-	res = EpisodeResponse{
-		BaseResponse: doRes,
-		Data:         episode,
-	}
+	return c.Episodes().Find(context.Background(), id)
+}
 
-	return res, nil
+// FindEpisodeContext will get a single item by uuid using the given context
+func (c *Client) FindEpisodeContext(ctx context.Context, id uuid.UUID) (EpisodeResponse, error) {
+	return c.Episodes().Find(ctx, id)
 }
 
 func (c *Client) RandomEpisode() (EpisodeResponse, error) {
-	var episode Episode
-	var res EpisodeResponse
-
-	doRes, err := c.doGetRequest(episodeOrigin+"/random", nil, &episode)
-	if err != nil {
-		return res, err
-	}
-
-	res = EpisodeResponse{
-		BaseResponse: doRes,
-		Data:         episode,
-	}
+	return c.Episodes().Random(context.Background())
+}
 
-	return res, nil
+func (c *Client) RandomEpisodeContext(ctx context.Context) (EpisodeResponse, error) {
+	return c.Episodes().Random(ctx)
 }
 
 func (c *Client) CreateEpisode(s CreateEpisode) (Episode, error) {
-	var episode Episode
-
-	err := c.doPostRequest(episodeOrigin, s, &episode)
-	if err != nil {
-		return episode, err
-	}
-
-	return episode, nil
+	return c.Episodes().Create(context.Background(), s)
 }
 
-func (c *Client) PatchEpisode(id uuid.UUID, p PatchEpisode) (Episode, error) {
-	var episode Episode
+func (c *Client) CreateEpisodeContext(ctx context.Context, s CreateEpisode) (Episode, error) {
+	return c.Episodes().Create(ctx, s)
+}
 
-	err := c.doPatchRequest(episodeOrigin+"/"+id.String(), &p)
-	if err != nil {
-		return episode, err
-	}
+func (c *Client) PatchEpisode(id uuid.UUID, p PatchEpisode, options ...WriteOptions) (Episode, error) {
+	return c.Episodes().Patch(context.Background(), id, p, options...)
+}
 
-	res, err := c.FindEpisode(id)
-	if err != nil {
-		return episode, err
-	}
+func (c *Client) PatchEpisodeContext(ctx context.Context, id uuid.UUID, p PatchEpisode, options ...WriteOptions) (Episode, error) {
+	return c.Episodes().Patch(ctx, id, p, options...)
+}
 
-	episode = res.Data
-	return episode, nil
+func (c *Client) DeleteEpisode(id uuid.UUID, options ...WriteOptions) error {
+	return c.Episodes().Delete(context.Background(), id, options...)
 }
 
-func (c *Client) DeleteEpisode(id uuid.UUID) error {
-	return c.doDeleteRequest(episodeOrigin + "/" + id.String())
+func (c *Client) DeleteEpisodeContext(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return c.Episodes().Delete(ctx, id, options...)
 }
@@ -1,6 +1,45 @@
 package hawapi
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySnippet is the max length of the body kept by HTTPError
+const maxErrorBodySnippet = 512
+
+// Errors used to classify failures with errors.Is
+var (
+	// ErrNotFound is matched by '404 Not Found' responses
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnauthorized is matched by '401 Unauthorized' responses
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden is matched by '403 Forbidden' responses
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrPreconditionFailed is matched by '412 Precondition Failed' responses,
+	// when the item was modified since its ETag was read (see WithIfMatch)
+	ErrPreconditionFailed = errors.New("precondition failed")
+
+	// ErrRateLimited is matched by '429 Too Many Requests' responses
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServer is matched by all 5xx responses
+	ErrServer = errors.New("server error")
+
+	// ErrQuotaExhausted is returned when the remaining quota reached the configured floor (see QuotaPolicy)
+	ErrQuotaExhausted = errors.New("quota exhausted")
+
+	// ErrTokenRequired is returned when a POST, PATCH or DELETE request is made without token
+	ErrTokenRequired = errors.New("token is required")
+
+	// ErrInvalidOut is returned when the value used to decode a response is not a pointer
+	ErrInvalidOut = errors.New("out must be a pointer")
+)
 
 type ErrorResponse struct {
 	Code    int    `json:"code"`
@@ -11,6 +50,11 @@ type ErrorResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+// fromAPI reports whether the decoded body is an API error, with its code, status or method
+func (e ErrorResponse) fromAPI() bool {
+	return e.Code != 0 || len(e.Status) != 0 || len(e.Method) != 0
+}
+
 func (e ErrorResponse) Error() string {
 	msg := fmt.Sprintf("request error [%s %d] using %s method", e.Status, e.Code, e.Method)
 
@@ -24,3 +68,75 @@ func (e ErrorResponse) Error() string {
 
 	return msg
 }
+
+// HTTPError is returned when an error response doesn't have an ErrorResponse body,
+// like a HTML page or an empty body sent by a proxy or load balancer in front of the API
+type HTTPError struct {
+	StatusCode int
+	Method     string
+	Url        string
+
+	// The beginning of the response body
+	Body   string
+	Header http.Header
+}
+
+func newHTTPError(req *http.Request, res *http.Response, body []byte) HTTPError {
+	snippet := strings.ToValidUTF8(string(body), "")
+	if len(snippet) > maxErrorBodySnippet {
+		snippet = strings.ToValidUTF8(snippet[:maxErrorBodySnippet], "") + "..."
+	}
+
+	return HTTPError{
+		StatusCode: res.StatusCode,
+		Method:     req.Method,
+		Url:        req.URL.String(),
+		Body:       snippet,
+		Header:     res.Header,
+	}
+}
+
+func (e HTTPError) Error() string {
+	msg := fmt.Sprintf("unexpected response [%d %s] using %s method on '%s'", e.StatusCode, http.StatusText(e.StatusCode), e.Method, e.Url)
+
+	if body := strings.TrimSpace(e.Body); len(body) != 0 {
+		msg = fmt.Sprintf("%s: %s", msg, body)
+	}
+
+	return msg
+}
+
+// Unwrap returns the error matching the status code (e.g. ErrServer), if any
+func (e HTTPError) Unwrap() error {
+	return errorForStatus(e.StatusCode)
+}
+
+// Is reports whether the target is an ErrorResponse with the same code
+func (e ErrorResponse) Is(target error) bool {
+	t, ok := target.(ErrorResponse)
+	return ok && t.Code == e.Code
+}
+
+// Unwrap returns the error matching the response code (e.g. ErrNotFound), if any
+func (e ErrorResponse) Unwrap() error {
+	return errorForStatus(e.Code)
+}
+
+// errorForStatus returns the error classifying a response status code
+func errorForStatus(code int) error {
+	switch {
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case code == http.StatusForbidden:
+		return ErrForbidden
+	case code == http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code >= 500 && code <= 599:
+		return ErrServer
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package hawapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "should match not found",
+			err:    ErrorResponse{Code: http.StatusNotFound},
+			target: ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "should match unauthorized",
+			err:    ErrorResponse{Code: http.StatusUnauthorized},
+			target: ErrUnauthorized,
+			want:   true,
+		},
+		{
+			name:   "should match forbidden",
+			err:    ErrorResponse{Code: http.StatusForbidden},
+			target: ErrForbidden,
+			want:   true,
+		},
+		{
+			name:   "should match precondition failed",
+			err:    ErrorResponse{Code: http.StatusPreconditionFailed},
+			target: ErrPreconditionFailed,
+			want:   true,
+		},
+		{
+			name:   "should match rate limited",
+			err:    ErrorResponse{Code: http.StatusTooManyRequests},
+			target: ErrRateLimited,
+			want:   true,
+		},
+		{
+			name:   "should match server error",
+			err:    ErrorResponse{Code: http.StatusBadGateway},
+			target: ErrServer,
+			want:   true,
+		},
+		{
+			name:   "should not match other class",
+			err:    ErrorResponse{Code: http.StatusBadRequest},
+			target: ErrNotFound,
+			want:   false,
+		},
+		{
+			name:   "should match error response with the same code",
+			err:    ErrorResponse{Code: http.StatusNotFound, Message: "lorem"},
+			target: ErrorResponse{Code: http.StatusNotFound},
+			want:   true,
+		},
+		{
+			name:   "should match wrapped error response",
+			err:    &RetryError{Attempts: 3, Err: ErrorResponse{Code: http.StatusServiceUnavailable}},
+			target: ErrServer,
+			want:   true,
+		},
+		{
+			name:   "should match wrapped sdk error",
+			err:    fmt.Errorf("%w for post request", ErrTokenRequired),
+			target: ErrTokenRequired,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_errors(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "actor not found"}`))
+	}))
+	defer sv.Close()
+
+	c := NewClientWithOpts(Options{
+		Endpoint:   sv.URL,
+		LogHandler: defaultTestLoggerHandler,
+	})
+
+	_, err := c.FindActorContext(context.Background(), uuid.New())
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindActor() error = %v, want %v", err, ErrNotFound)
+	}
+
+	_, err = c.CreateActorContext(context.Background(), CreateActor{})
+	if !errors.Is(err, ErrTokenRequired) {
+		t.Errorf("CreateActor() error = %v, want %v", err, ErrTokenRequired)
+	}
+
+	var actor Actor
+	_, err = c.doRequest(httptest.NewRequest(http.MethodGet, sv.URL, nil), http.StatusOK, actor)
+	if !errors.Is(err, ErrInvalidOut) {
+		t.Errorf("doRequest() error = %v, want %v", err, ErrInvalidOut)
+	}
+}
+
+func TestClient_doRequest_httpError(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockStatus  int
+		mockBody    string
+		wantErr     error
+		wantBody    string
+		wantHeaders bool
+	}{
+		{
+			name:       "should keep html body from a gateway",
+			mockStatus: http.StatusBadGateway,
+			mockBody:   "<html><body>502 Bad Gateway</body></html>",
+			wantErr:    ErrServer,
+			wantBody:   "<html><body>502 Bad Gateway</body></html>",
+		},
+		{
+			name:       "should keep json body from a gateway",
+			mockStatus: http.StatusBadGateway,
+			mockBody:   `{"message": "Internal server error"}`,
+			wantErr:    ErrServer,
+			wantBody:   `{"message": "Internal server error"}`,
+		},
+		{
+			name:       "should handle a null body",
+			mockStatus: http.StatusServiceUnavailable,
+			mockBody:   "null",
+			wantErr:    ErrServer,
+			wantBody:   "null",
+		},
+		{
+			name:       "should handle an empty body",
+			mockStatus: http.StatusUnauthorized,
+			mockBody:   "",
+			wantErr:    ErrUnauthorized,
+			wantBody:   "",
+		},
+		{
+			name:       "should truncate long bodies",
+			mockStatus: http.StatusForbidden,
+			mockBody:   strings.Repeat("a", maxErrorBodySnippet+10),
+			wantErr:    ErrForbidden,
+			wantBody:   strings.Repeat("a", maxErrorBodySnippet) + "...",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "lorem")
+				w.WriteHeader(tt.mockStatus)
+				w.Write([]byte(tt.mockBody))
+			}))
+			defer sv.Close()
+
+			c := NewClientWithOpts(Options{
+				LogHandler: defaultTestLoggerHandler,
+				Retry:      RetryPolicy{MaxAttempts: 1},
+			})
+
+			req, err := http.NewRequest(http.MethodGet, sv.URL+"/actors", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = c.doRequest(req, http.StatusOK, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("doRequest() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var httpErr HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("doRequest() error = %T, want HTTPError", err)
+			}
+
+			if httpErr.StatusCode != tt.mockStatus || httpErr.Method != http.MethodGet || httpErr.Url != sv.URL+"/actors" {
+				t.Errorf("HTTPError = %+v", httpErr)
+			}
+
+			if httpErr.Body != tt.wantBody {
+				t.Errorf("HTTPError.Body = %v, want %v", httpErr.Body, tt.wantBody)
+			}
+
+			if httpErr.Header.Get("X-Request-Id") != "lorem" {
+				t.Errorf("HTTPError.Header = %v", httpErr.Header)
+			}
+		})
+	}
+}
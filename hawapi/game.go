@@ -1,6 +1,9 @@
 package hawapi
 
 import (
+	"context"
+	"iter"
+
 	"github.com/google/uuid"
 )
 
@@ -52,99 +55,90 @@ type CreateGame struct {
 	Sources     []string `json:"sources,omitempty"`
 }
 
-type PatchGame = CreateGame
-
-type GameResponse struct {
-	BaseResponse
-	Data Game `json:"data"`
+// PatchGame is a JSON Merge Patch, only its set fields are updated
+type PatchGame struct {
+	Name        Optional[string]   `json:"name,omitzero"`
+	Description Optional[string]   `json:"description,omitzero"`
+	Playtime    Optional[int64]    `json:"playtime,omitzero"`
+	Language    Optional[string]   `json:"language,omitzero"`
+	Platforms   Optional[[]string] `json:"platforms,omitzero"`
+	Stores      Optional[[]string] `json:"stores,omitzero"`
+	Modes       Optional[[]string] `json:"modes,omitzero"`
+	Genres      Optional[[]string] `json:"genres,omitzero"`
+	Publishers  Optional[[]string] `json:"publishers,omitzero"`
+	Developers  Optional[[]string] `json:"developers,omitzero"`
+	Website     Optional[string]   `json:"website,omitzero"`
+	Tags        Optional[[]string] `json:"tags,omitzero"`
+	Trailer     Optional[string]   `json:"trailer,omitzero"`
+	AgeRating   Optional[string]   `json:"age_rating,omitzero"`
+	ReleaseDate Optional[string]   `json:"release_date,omitzero"`
+	Thumbnail   Optional[string]   `json:"thumbnail,omitzero"`
+	Images      Optional[[]string] `json:"images,omitzero"`
+	Sources     Optional[[]string] `json:"sources,omitzero"`
 }
 
-type GameListResponse struct {
-	BaseResponse
-	Data []Game `json:"data"`
+type GameResponse = ItemResponse[Game]
+
+type GameListResponse = ListResponse[Game]
+
+// Games returns the typed client for games
+func (c *Client) Games() Resource[Game, CreateGame, PatchGame] {
+	return NewResource[Game, CreateGame, PatchGame](c, gameOrigin)
 }
 
 // ListGames will get all games
 func (c *Client) ListGames(options ...QueryOptions) (GameListResponse, error) {
-	var games []Game
-	var res GameListResponse
-
-	doRes, err := c.doGetRequest(gameOrigin, options, &games)
-	if err != nil {
-		return res, err
-	}
+	return c.Games().List(context.Background(), options...)
+}
 
-	res = GameListResponse{
-		BaseResponse: doRes,
-		Data:         games,
-	}
+// ListGamesContext will get all games using the given context
+func (c *Client) ListGamesContext(ctx context.Context, options ...QueryOptions) (GameListResponse, error) {
+	return c.Games().List(ctx, options...)
+}
 
-	return res, nil
+// AllGames iterates over all games of every page, starting at the page defined by the options
+func (c *Client) AllGames(ctx context.Context, options ...QueryOptions) iter.Seq2[Game, error] {
+	return c.Games().All(ctx, options...)
 }
 
 // FindGame will get a single item by uuid
 func (c *Client) FindGame(id uuid.UUID) (GameResponse, error) {
-	var game Game
-	var res GameResponse
-
-	doRes, err := c.doGetRequest(gameOrigin+"/"+id.String(), nil, &game)
-	if err != nil {
-		return res, err
-	}
-
-	res = GameResponse{
-		BaseResponse: doRes,
-		Data:         game,
-	}
+	return c.Games().Find(context.Background(), id)
+}
 
-	return res, nil
+// FindGameContext will get a single item by uuid using the given context
+func (c *Client) FindGameContext(ctx context.Context, id uuid.UUID) (GameResponse, error) {
+	return c.Games().Find(ctx, id)
 }
 
 func (c *Client) RandomGame() (GameResponse, error) {
-	var game Game
-	var res GameResponse
-
-	doRes, err := c.doGetRequest(gameOrigin+"/random", nil, &game)
-	if err != nil {
-		return res, err
-	}
-
-	res = GameResponse{
-		BaseResponse: doRes,
-		Data:         game,
-	}
+	return c.Games().Random(context.Background())
+}
 
-	return res, nil
+func (c *Client) RandomGameContext(ctx context.Context) (GameResponse, error) {
+	return c.Games().Random(ctx)
 }
 
 func (c *Client) CreateGame(s CreateGame) (Game, error) {
-	var game Game
-
-	err := c.doPostRequest(gameOrigin, s, &game)
-	if err != nil {
-		return game, err
-	}
-
-	return game, nil
+	return c.Games().Create(context.Background(), s)
 }
 
-func (c *Client) PatchGame(id uuid.UUID, p PatchGame) (Game, error) {
-	var game Game
+func (c *Client) CreateGameContext(ctx context.Context, s CreateGame) (Game, error) {
+	return c.Games().Create(ctx, s)
+}
 
-	err := c.doPatchRequest(gameOrigin+"/"+id.String(), &p)
-	if err != nil {
-		return game, err
-	}
+func (c *Client) PatchGame(id uuid.UUID, p PatchGame, options ...WriteOptions) (Game, error) {
+	return c.Games().Patch(context.Background(), id, p, options...)
+}
 
-	res, err := c.FindGame(id)
-	if err != nil {
-		return game, err
-	}
+func (c *Client) PatchGameContext(ctx context.Context, id uuid.UUID, p PatchGame, options ...WriteOptions) (Game, error) {
+	return c.Games().Patch(ctx, id, p, options...)
+}
 
-	game = res.Data
-	return game, nil
+func (c *Client) DeleteGame(id uuid.UUID, options ...WriteOptions) error {
+	return c.Games().Delete(context.Background(), id, options...)
 }
 
-func (c *Client) DeleteGame(id uuid.UUID) error {
-	return c.doDeleteRequest(gameOrigin + "/" + id.String())
+func (c *Client) DeleteGameContext(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return c.Games().Delete(ctx, id, options...)
 }
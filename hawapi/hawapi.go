@@ -1,7 +1,9 @@
 package hawapi
 
 import (
+	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"time"
@@ -17,6 +19,11 @@ const (
 	DefaultSize             = 10
 	DefaultTimeout          = 10
 	DefaultUseInMemoryCache = true
+	DefaultCacheMaxEntries  = 1000
+	DefaultCacheTTL         = 10 * time.Minute
+	DefaultCacheShards      = 16
+	DefaultCacheMaxAge      = time.Minute
+	DefaultCacheMaxBytes    = 64 << 20
 )
 
 // DefaultOptions for Go HawAPI SDK
@@ -27,8 +34,14 @@ var DefaultOptions = Options{
 	Size:             DefaultSize,
 	Timeout:          DefaultTimeout,
 	UseInMemoryCache: DefaultUseInMemoryCache,
+	CacheMaxEntries:  DefaultCacheMaxEntries,
+	CacheTTL:         DefaultCacheTTL,
+	CacheMaxAge:      DefaultCacheMaxAge,
+	CacheMaxBytes:    DefaultCacheMaxBytes,
+	CachePolicies:    DefaultCachePolicies,
 	LogLevel:         DefaultLogLevel,
 	LogHandler:       nil,
+	Retry:            DefaultRetryPolicy,
 }
 
 type Options struct {
@@ -50,17 +63,99 @@ type Options struct {
 	// Note: This value can be overwritten later
 	Size int
 
-	// The timeout of a response in milliseconds
+	// The timeout of a response in seconds
+	//
+	// Only used if the HTTPClient doesn't define its own timeout
 	Timeout int
 
+	// Defines a custom http client, e.g. to use a proxy or custom TLS roots
+	//
+	// The client is copied, the SDK behaviour (retries, quota, cache...) is applied on top of it
+	HTTPClient *http.Client
+
+	// Defines a custom transport, replacing the HTTPClient transport
+	//
+	// Useful to tune the connection pool or add instrumentation
+	Transport http.RoundTripper
+
+	// Defines middlewares wrapping every request sent by the client
+	//
+	// The first middleware is the outermost: it receives the request first and the response last
+	Middlewares []Middleware
+
 	// The HawAPI token (JWT)
 	//
 	// By default, all requests are made with 'ANONYMOUS' tier
 	Token string
 
-	// Define if the package should save (in-memory) all request results
+	// Defines where the token is read from, e.g. FileTokenSource or a TokenSourceFunc
+	//
+	// The token is refreshed before its 'exp' claim, and once after a '401 Unauthorized' response.
+	// If set, Token is ignored
+	TokenSource TokenSource
+
+	// Define if the token returned by Client.Register and Client.Authenticate
+	// replaces Token and TokenSource
+	AdoptAuthToken bool
+
+	// Define how failed requests are retried
+	//
+	// Only the non-zero fields replace the ones of the current policy,
+	// e.g. RetryPolicy{RetryMutations: true} keeps the default attempts and delays
+	//
+	// Default value: DefaultRetryPolicy
+	Retry RetryPolicy
+
+	// Define what happens when the remaining quota reaches a floor
+	//
+	// By default, the quota is only tracked (see Client.Quota)
+	Quota QuotaPolicy
+
+	// Define if the package should save (in-memory, or in CacheDir) all request results
 	UseInMemoryCache bool
 
+	// The max number of cached responses, the least recently used are evicted first
+	//
+	// Default value: DefaultCacheMaxEntries
+	CacheMaxEntries int
+
+	// How long a cached response is kept
+	//
+	// Default value: DefaultCacheTTL
+	CacheTTL time.Duration
+
+	// How long a cached response is used without asking the API
+	//
+	// Once stale, responses with an ETag are revalidated using a conditional request ('If-None-Match')
+	//
+	// Default value: DefaultCacheMaxAge
+	CacheMaxAge time.Duration
+
+	// Define if and how long the responses of each Endpoint are cached,
+	// the policies of the given endpoints override the default ones
+	//
+	// Default value: DefaultCachePolicies
+	CachePolicies map[Endpoint]CachePolicy
+
+	// Define if a stale cached response is served when the API fails (5xx, rate limit, network error...),
+	// instead of returning the error
+	CacheServeStaleOnError bool
+
+	// Define a directory where responses are cached, kept between runs
+	//
+	// If set, the default cache backend is a cache.NewDiskCache using CacheTTL and CacheMaxBytes
+	CacheDir string
+
+	// The max size of the responses cached in CacheDir, in bytes
+	//
+	// Default value: DefaultCacheMaxBytes
+	CacheMaxBytes int64
+
+	// Defines a custom cache backend
+	//
+	// If set to nil, it defaults to a cache.NewLRUCache using CacheMaxEntries and CacheTTL
+	Cache cache.Cache
+
 	// Define the level of SDK logging
 	//
 	// NOTE: If you are using a custom LogHandler, use slog.HandlerOptions to define a new log level or the SDK will panic
@@ -77,29 +172,38 @@ type Options struct {
 //   - [GitHub]
 //   - [Examples]
 //
+// A Client is safe for concurrent use by multiple goroutines. Its options
+// (see WithOpts) must be defined before sharing it.
+//
 // [HawAPI]: https://github.com/HawAPI/HawAPI
 // [GitHub]: https://github.com/HawAPI/go-sdk/
 // [Examples]: https://github.com/HawAPI/go-sdk/examples/
 type Client struct {
 	options Options
 	client  *http.Client
+	doer    Doer
 	logger  *slog.Logger
 	cache   cache.Cache
+	quota   *quotaTracker
+	tokens  *tokenCache
+	stats   *cacheStats
 }
 
 // NewClient creates a new HawAPI client using the default options.
 func NewClient() Client {
 	c := Client{options: DefaultOptions}
 
-	c.client = &http.Client{
-		Timeout: time.Duration(c.options.Timeout) * time.Second,
-	}
+	c.client = c.newHTTPClient()
+	c.doer = c.client
 
 	c.logger = slog.New(NewFormattedHandler(os.Stdout, &slog.HandlerOptions{
 		Level: c.options.LogLevel,
 	}))
 
-	c.cache = cache.NewMemoryCache()
+	c.stats = newCacheStats()
+	c.cache = c.newCache()
+	c.quota = newQuotaTracker()
+	c.tokens = newTokenCache(nil)
 	return c
 }
 
@@ -111,6 +215,8 @@ func NewClientWithOpts(options Options) Client {
 }
 
 // WithOpts will set or override current client options
+//
+// It must not be called while the client is being used by other goroutines
 func (c *Client) WithOpts(options Options) {
 	if len(options.Endpoint) != 0 {
 		c.options.Endpoint = options.Endpoint
@@ -132,6 +238,53 @@ func (c *Client) WithOpts(options Options) {
 		c.options.Timeout = options.Timeout
 	}
 
+	if options.HTTPClient != nil {
+		c.options.HTTPClient = options.HTTPClient
+	}
+
+	if options.Transport != nil {
+		c.options.Transport = options.Transport
+	}
+
+	// Timeout, HTTPClient and Transport are all applied to the http client
+	if options.Timeout != 0 || options.HTTPClient != nil || options.Transport != nil {
+		c.client = c.newHTTPClient()
+	}
+
+	if options.Middlewares != nil {
+		c.options.Middlewares = options.Middlewares
+	}
+
+	c.doer = chain(c.client, c.options.Middlewares)
+
+	if len(options.Token) != 0 {
+		c.options.Token = options.Token
+	}
+
+	if options.TokenSource != nil {
+		c.options.TokenSource = options.TokenSource
+	}
+
+	if c.tokens == nil {
+		c.tokens = newTokenCache(nil)
+	}
+
+	if options.TokenSource != nil {
+		c.tokens.setSource(c.options.TokenSource)
+	} else if len(options.Token) != 0 && c.options.TokenSource == nil {
+		c.tokens.setSource(StaticTokenSource(c.options.Token))
+	}
+
+	if options.AdoptAuthToken {
+		c.options.AdoptAuthToken = true
+	}
+
+	c.options.Retry.merge(options.Retry)
+
+	if options.Quota.Mode != QuotaIgnore {
+		c.options.Quota = options.Quota
+	}
+
 	if options.LogLevel != DefaultLogLevel {
 		c.options.LogLevel = options.LogLevel
 
@@ -148,6 +301,47 @@ func (c *Client) WithOpts(options Options) {
 		c.logger = slog.New(options.LogHandler)
 	}
 
+	if options.Cache != nil {
+		c.cache = options.Cache
+	} else if options.CacheMaxEntries != 0 || options.CacheTTL != 0 || len(options.CacheDir) != 0 || options.CacheMaxBytes != 0 {
+		if options.CacheMaxEntries != 0 {
+			c.options.CacheMaxEntries = options.CacheMaxEntries
+		}
+
+		if len(options.CacheDir) != 0 {
+			c.options.CacheDir = options.CacheDir
+		}
+
+		if options.CacheMaxBytes != 0 {
+			c.options.CacheMaxBytes = options.CacheMaxBytes
+		}
+
+		if options.CacheTTL != 0 {
+			c.options.CacheTTL = options.CacheTTL
+		}
+
+		c.cache = c.newCache()
+	}
+
+	if options.CacheMaxAge != 0 {
+		c.options.CacheMaxAge = options.CacheMaxAge
+	}
+
+	if options.CacheServeStaleOnError {
+		c.options.CacheServeStaleOnError = true
+	}
+
+	if options.CachePolicies != nil {
+		// Copy it, so DefaultCachePolicies is never modified
+		policies := maps.Clone(c.options.CachePolicies)
+		if policies == nil {
+			policies = make(map[Endpoint]CachePolicy)
+		}
+
+		maps.Copy(policies, options.CachePolicies)
+		c.options.CachePolicies = policies
+	}
+
 	if !options.UseInMemoryCache {
 		c.logger.Warn("Using WithOpts method, the value of UseInMemoryCache will be set to false")
 	}
@@ -155,6 +349,65 @@ func (c *Client) WithOpts(options Options) {
 	c.options.UseInMemoryCache = options.UseInMemoryCache
 }
 
+// newHTTPClient creates the http client using the client options
+func (c *Client) newHTTPClient() *http.Client {
+	client := &http.Client{}
+
+	// Copy it, so the given client is never modified
+	if c.options.HTTPClient != nil {
+		copied := *c.options.HTTPClient
+		client = &copied
+	}
+
+	if c.options.Transport != nil {
+		client.Transport = c.options.Transport
+	}
+
+	if client.Timeout == 0 {
+		client.Timeout = time.Duration(c.options.Timeout) * time.Second
+	}
+
+	return client
+}
+
+// newCache creates the default cache backend using the client options
+func (c *Client) newCache() cache.Cache {
+	stats := c.stats
+	onEvict := func(_ string, value any) {
+		if cbr, ok := value.(cachedBaseResponse); ok {
+			stats.record(cbr.origin, cacheEviction)
+		}
+	}
+
+	if len(c.options.CacheDir) != 0 {
+		// The disk cache only gives the key of evicted entries, without reading their files
+		prefix := c.cacheKeyPrefix()
+		diskCache, err := cache.NewDiskCache(cache.DiskOptions{
+			Dir:      c.options.CacheDir,
+			TTL:      c.options.CacheTTL,
+			MaxBytes: c.options.CacheMaxBytes,
+			Codec:    cachedResponseCodec{},
+			OnEvict: func(key string) {
+				if origin, ok := originOf(prefix, key); ok {
+					stats.record(origin, cacheEviction)
+				}
+			},
+		})
+		if err == nil {
+			return diskCache
+		}
+
+		c.logger.Warn(fmt.Sprintf("failed to use the cache dir, using an in-memory cache: %s", err))
+	}
+
+	return cache.NewLRUCache(cache.Options{
+		MaxEntries: c.options.CacheMaxEntries,
+		TTL:        c.options.CacheTTL,
+		Shards:     DefaultCacheShards,
+		OnEvict:    onEvict,
+	})
+}
+
 // ClearCache deletes all values from the cache and returns the count of deleted items
 func (c *Client) ClearCache() int {
 	return c.cache.Clear()
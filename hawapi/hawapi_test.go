@@ -0,0 +1,86 @@
+package hawapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingTransport struct {
+	count atomic.Int32
+	next  http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count.Add(1)
+	return t.next.RoundTrip(req)
+}
+
+func TestClient_httpClient(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer sv.Close()
+
+	tests := []struct {
+		name        string
+		options     func(transport http.RoundTripper) Options
+		wantTimeout time.Duration
+	}{
+		{
+			name: "should use custom transport",
+			options: func(transport http.RoundTripper) Options {
+				return Options{Transport: transport}
+			},
+			wantTimeout: DefaultTimeout * time.Second,
+		},
+		{
+			name: "should use custom http client",
+			options: func(transport http.RoundTripper) Options {
+				return Options{
+					HTTPClient: &http.Client{Transport: transport, Timeout: time.Minute},
+					Timeout:    30,
+				}
+			},
+			wantTimeout: time.Minute,
+		},
+		{
+			name: "should apply sdk timeout to custom http client",
+			options: func(transport http.RoundTripper) Options {
+				return Options{
+					HTTPClient: &http.Client{Transport: transport},
+					Timeout:    30,
+				}
+			},
+			wantTimeout: 30 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &countingTransport{next: http.DefaultTransport}
+
+			options := tt.options(transport)
+			options.Endpoint = sv.URL
+			options.LogHandler = defaultTestLoggerHandler
+			c := NewClientWithOpts(options)
+
+			if _, err := c.Info(); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := transport.count.Load(); got != 1 {
+				t.Errorf("transport requests = %v, want %v", got, 1)
+			}
+
+			if c.client.Timeout != tt.wantTimeout {
+				t.Errorf("timeout = %v, want %v", c.client.Timeout, tt.wantTimeout)
+			}
+
+			if options.HTTPClient != nil && options.HTTPClient == c.client {
+				t.Error("custom http client should be copied")
+			}
+		})
+	}
+}
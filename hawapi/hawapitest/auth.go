@@ -0,0 +1,93 @@
+package hawapitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+	"github.com/google/uuid"
+)
+
+// DefaultUserToken is the token returned by the auth endpoints when Options.Token is empty
+const DefaultUserToken = "token"
+
+type user struct {
+	hawapi.User
+	password string
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var register hawapi.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&register); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	if len(register.Username) == 0 || len(register.Email) == 0 || len(register.Password) == 0 {
+		writeError(w, r, http.StatusBadRequest, "username, email and password are required")
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	u := user{
+		User: hawapi.User{
+			Uuid:      uuid.New(),
+			Username:  register.Username,
+			FirstName: register.FirstName,
+			LastName:  register.LastName,
+			Email:     register.Email,
+			Role:      "BASIC",
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		password: register.Password,
+	}
+
+	s.mu.Lock()
+	_, exists := s.users[register.Email]
+	if !exists {
+		s.users[register.Email] = u
+	}
+	s.mu.Unlock()
+
+	if exists {
+		writeError(w, r, http.StatusConflict, "email already registered")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, s.authResponse(u))
+}
+
+func (s *Server) handleAuthenticate(w http.ResponseWriter, r *http.Request) {
+	var credentials hawapi.Credentials
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	s.mu.Lock()
+	u, ok := s.users[credentials.Email]
+	s.mu.Unlock()
+
+	if !ok || u.password != credentials.Password {
+		writeError(w, r, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, s.authResponse(u))
+}
+
+// authResponse returns the user with the token accepted by the Server
+func (s *Server) authResponse(u user) hawapi.AuthResponse {
+	token := s.options.Token
+	if len(token) == 0 {
+		token = DefaultUserToken
+	}
+
+	return hawapi.AuthResponse{
+		User:      u.User,
+		Token:     token,
+		TokenType: "Bearer",
+	}
+}
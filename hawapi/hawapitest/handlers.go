@@ -0,0 +1,334 @@
+package hawapitest
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+	"github.com/google/uuid"
+)
+
+// reservedParams are the query params which are not used as filters
+var reservedParams = []string{"language", "page", "size", "sort"}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, hawapi.Info{
+		Title:       "HawAPI",
+		Description: "In-memory HawAPI test server",
+		Version:     s.options.Version,
+		Url:         s.URL,
+		ApiUrl:      s.Endpoint(),
+		ApiVersion:  s.options.Version,
+		ApiPath:     "/api",
+		ApiBaseUrl:  s.Endpoint() + "/" + s.options.Version,
+	})
+}
+
+func (s *Server) handleOverview(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("version") != s.options.Version {
+		writeError(w, r, http.StatusNotFound, "unknown version")
+		return
+	}
+
+	s.mu.Lock()
+	count := hawapi.DataCount{
+		Actors:      len(s.resources["actors"].order),
+		Characters:  len(s.resources["characters"].order),
+		Episodes:    len(s.resources["episodes"].order),
+		Games:       len(s.resources["games"].order),
+		Locations:   len(s.resources["locations"].order),
+		Seasons:     len(s.resources["seasons"].order),
+		Soundtracks: len(s.resources["soundtracks"].order),
+	}
+	s.mu.Unlock()
+
+	language := languageOf(r)
+	w.Header().Set("Content-Language", language)
+	writeJSON(w, r, http.StatusOK, hawapi.Overview{
+		Href:      "/api/" + s.options.Version + "/overview",
+		Title:     "HawAPI",
+		Language:  language,
+		Languages: []string{language},
+		DataCount: count,
+	})
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.resource(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filters := make(map[string]string)
+	for key := range query {
+		if !slices.Contains(reservedParams, key) {
+			filters[key] = query.Get(key)
+		}
+	}
+
+	page, size, ok := pageable(w, r)
+	if !ok {
+		return
+	}
+
+	language := languageOf(r)
+
+	s.mu.Lock()
+	items := st.filter(language, filters)
+	s.mu.Unlock()
+
+	if sort := query.Get("sort"); len(sort) != 0 {
+		sortItems(items, sort)
+	}
+
+	pageTotal := (len(items) + size - 1) / size
+	start := min((page-1)*size, len(items))
+	end := min(start+size, len(items))
+
+	h := w.Header()
+	h.Set("Content-Language", language)
+	h.Set("X-Pagination-Page-Index", strconv.Itoa(page))
+	h.Set("X-Pagination-Page-Size", strconv.Itoa(size))
+	h.Set("X-Pagination-Page-Total", strconv.Itoa(pageTotal))
+	h.Set("X-Pagination-Item-Total", strconv.Itoa(len(items)))
+
+	writeJSON(w, r, http.StatusOK, append([]map[string]any{}, items[start:end]...))
+}
+
+func (s *Server) handleRandom(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.resource(w, r)
+	if !ok {
+		return
+	}
+
+	language := languageOf(r)
+
+	s.mu.Lock()
+	items := st.filter(language, nil)
+	s.mu.Unlock()
+
+	if len(items) == 0 {
+		writeError(w, r, http.StatusNotFound, "no items found")
+		return
+	}
+
+	w.Header().Set("Content-Language", language)
+	writeJSON(w, r, http.StatusOK, items[rand.IntN(len(items))])
+}
+
+func (s *Server) handleFind(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.resource(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := parseUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	item, found := st.get(id)
+	s.mu.Unlock()
+
+	if !found {
+		writeError(w, r, http.StatusNotFound, "item not found")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, item)
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.resource(w, r)
+	if !ok || !s.authorize(w, r) {
+		return
+	}
+
+	var obj map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil || obj == nil {
+		writeError(w, r, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	delete(obj, "created_at")
+	delete(obj, "updated_at")
+
+	id := uuid.New()
+
+	s.mu.Lock()
+	item := s.withMetadata(r.PathValue("origin"), id, obj)
+	st.put(id, item)
+	s.mu.Unlock()
+
+	writeJSON(w, r, http.StatusCreated, item)
+}
+
+func (s *Server) handlePatch(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.resource(w, r)
+	if !ok || !s.authorize(w, r) {
+		return
+	}
+
+	id, ok := parseUUID(w, r)
+	if !ok {
+		return
+	}
+
+	var patch map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || patch == nil {
+		writeError(w, r, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, found := st.get(id)
+	if !found {
+		writeError(w, r, http.StatusNotFound, "item not found")
+		return
+	}
+
+	if !matchesETag(r, item) {
+		writeError(w, r, http.StatusPreconditionFailed, "item was modified")
+		return
+	}
+
+	// JSON Merge Patch (RFC 7396), a null value removes the field
+	merged := make(map[string]any, len(item))
+	for key, value := range item {
+		merged[key] = value
+	}
+
+	for key, value := range patch {
+		switch key {
+		case "uuid", "href", "created_at", "updated_at":
+			continue
+		}
+
+		if value == nil {
+			delete(merged, key)
+		} else {
+			merged[key] = value
+		}
+	}
+
+	merged["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+	st.put(id, merged)
+
+	writeJSON(w, r, http.StatusOK, merged)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.resource(w, r)
+	if !ok || !s.authorize(w, r) {
+		return
+	}
+
+	id, ok := parseUUID(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, found := st.get(id)
+	if !found {
+		writeError(w, r, http.StatusNotFound, "item not found")
+		return
+	}
+
+	if !matchesETag(r, item) {
+		writeError(w, r, http.StatusPreconditionFailed, "item was modified")
+		return
+	}
+
+	st.delete(id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resource returns the store of the requested resource, or writes a '404 Not Found' error
+func (s *Server) resource(w http.ResponseWriter, r *http.Request) (*store, bool) {
+	if r.PathValue("version") != s.options.Version {
+		writeError(w, r, http.StatusNotFound, "unknown version")
+		return nil, false
+	}
+
+	st, ok := s.resources[r.PathValue("origin")]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "unknown resource")
+		return nil, false
+	}
+
+	return st, true
+}
+
+// authorize checks the bearer token, or writes a '401 Unauthorized' or '403 Forbidden' error
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || len(token) == 0 {
+		writeError(w, r, http.StatusUnauthorized, "missing bearer token")
+		return false
+	}
+
+	if len(s.options.Token) != 0 && token != s.options.Token {
+		writeError(w, r, http.StatusForbidden, "invalid bearer token")
+		return false
+	}
+
+	return true
+}
+
+func parseUUID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue("uuid"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid uuid")
+		return id, false
+	}
+	return id, true
+}
+
+func pageable(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	page, size := 1, hawapi.DefaultSize
+
+	query := r.URL.Query()
+	if v := query.Get("page"); len(v) != 0 {
+		p, err := strconv.Atoi(v)
+		if err != nil || p < 1 {
+			writeError(w, r, http.StatusBadRequest, "invalid page")
+			return 0, 0, false
+		}
+		page = p
+	}
+
+	if v := query.Get("size"); len(v) != 0 {
+		s, err := strconv.Atoi(v)
+		if err != nil || s < 1 {
+			writeError(w, r, http.StatusBadRequest, "invalid size")
+			return 0, 0, false
+		}
+		size = s
+	}
+
+	return page, size, true
+}
+
+func languageOf(r *http.Request) string {
+	if language := r.URL.Query().Get("language"); len(language) != 0 {
+		return language
+	}
+	return hawapi.DefaultLanguage
+}
+
+func statusName(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
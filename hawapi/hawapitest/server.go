@@ -0,0 +1,266 @@
+// Package hawapitest provides an in-memory HawAPI server, to be used by tests through Options.Endpoint.
+//
+//	srv := hawapitest.NewServer(hawapitest.Options{Token: "token"})
+//	defer srv.Close()
+//
+//	srv.Seed(hawapi.Actor{FirstName: "Lorem", LastName: "Ipsum"})
+//
+//	client := hawapi.NewClientWithOpts(hawapi.Options{
+//		Endpoint: srv.Endpoint(),
+//		Token:    "token",
+//	})
+package hawapitest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+	"github.com/google/uuid"
+)
+
+// Origins lists all resources served by the Server
+var Origins = []string{"actors", "characters", "episodes", "games", "locations", "seasons", "soundtracks"}
+
+type Options struct {
+	// The token required by POST, PATCH and DELETE requests
+	//
+	// If empty, any bearer token is accepted
+	Token string
+
+	// The version of the API
+	//
+	// Default value: hawapi.DefaultVersion
+	Version string
+
+	// The quota reported by 'X-Rate-Limit-Remaining', decreased on every request.
+	// Once exhausted, requests fail with '429 Too Many Requests'
+	//
+	// Set it to 0 for an unlimited quota, without the header
+	Quota int
+}
+
+// Server is an in-memory HawAPI server
+type Server struct {
+	*httptest.Server
+
+	options Options
+
+	mu        sync.Mutex
+	resources map[string]*store
+	users     map[string]user
+	quota     int
+}
+
+// NewServer starts a new Server, which should be closed when finished
+func NewServer(options Options) *Server {
+	if len(options.Version) == 0 {
+		options.Version = hawapi.DefaultVersion
+	}
+
+	s := &Server{
+		options:   options,
+		resources: make(map[string]*store),
+		users:     make(map[string]user),
+		quota:     options.Quota,
+	}
+
+	for _, origin := range Origins {
+		s.resources[origin] = newStore()
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api", s.handleInfo)
+	mux.HandleFunc("GET /api/{version}/overview", s.handleOverview)
+	mux.HandleFunc("POST /api/{version}/auth/register", s.handleRegister)
+	mux.HandleFunc("POST /api/{version}/auth/authenticate", s.handleAuthenticate)
+	mux.HandleFunc("GET /api/{version}/{origin}", s.handleList)
+	mux.HandleFunc("GET /api/{version}/{origin}/random", s.handleRandom)
+	mux.HandleFunc("GET /api/{version}/{origin}/{uuid}", s.handleFind)
+	mux.HandleFunc("POST /api/{version}/{origin}", s.handleCreate)
+	mux.HandleFunc("PATCH /api/{version}/{origin}/{uuid}", s.handlePatch)
+	mux.HandleFunc("DELETE /api/{version}/{origin}/{uuid}", s.handleDelete)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, http.StatusNotFound, "no handler found")
+	})
+
+	s.Server = httptest.NewServer(s.limit(mux))
+	return s
+}
+
+// Endpoint returns the value to use as hawapi.Options.Endpoint
+func (s *Server) Endpoint() string {
+	return s.URL + "/api"
+}
+
+// Seed stores the items (e.g. hawapi.Actor), generating their uuid if not defined.
+//
+// It panics if an item is not a HawAPI resource.
+func (s *Server) Seed(items ...any) {
+	for _, item := range items {
+		origin := originOf(item)
+		if len(origin) == 0 {
+			panic(fmt.Sprintf("hawapitest: unsupported type %T", item))
+		}
+
+		b, err := json.Marshal(item)
+		if err != nil {
+			panic(fmt.Sprintf("hawapitest: %s", err))
+		}
+
+		var obj map[string]any
+		if err := json.Unmarshal(b, &obj); err != nil {
+			panic(fmt.Sprintf("hawapitest: %s", err))
+		}
+
+		id, err := uuid.Parse(fmt.Sprint(obj["uuid"]))
+		if err != nil || id == uuid.Nil {
+			id = uuid.New()
+		}
+
+		s.mu.Lock()
+		s.resources[origin].put(id, s.withMetadata(origin, id, obj))
+		s.mu.Unlock()
+	}
+}
+
+// Len returns the count of items of a resource (e.g. 'actors')
+func (s *Server) Len(origin string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if st, ok := s.resources[origin]; ok {
+		return len(st.order)
+	}
+	return 0
+}
+
+// Quota returns the remaining quota
+func (s *Server) Quota() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.quota
+}
+
+func originOf(item any) string {
+	switch item.(type) {
+	case hawapi.Actor, *hawapi.Actor:
+		return "actors"
+	case hawapi.Character, *hawapi.Character:
+		return "characters"
+	case hawapi.Episode, *hawapi.Episode:
+		return "episodes"
+	case hawapi.Game, *hawapi.Game:
+		return "games"
+	case hawapi.Location, *hawapi.Location:
+		return "locations"
+	case hawapi.Season, *hawapi.Season:
+		return "seasons"
+	case hawapi.Soundtrack, *hawapi.Soundtrack:
+		return "soundtracks"
+	}
+	return ""
+}
+
+// withMetadata sets the fields managed by the API
+func (s *Server) withMetadata(origin string, id uuid.UUID, obj map[string]any) map[string]any {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	obj["uuid"] = id.String()
+	obj["href"] = fmt.Sprintf("/api/%s/%s/%s", s.options.Version, origin, id)
+
+	if v, ok := obj["created_at"].(string); !ok || len(v) == 0 {
+		obj["created_at"] = now
+	}
+
+	if v, ok := obj["updated_at"].(string); !ok || len(v) == 0 {
+		obj["updated_at"] = now
+	}
+
+	return obj
+}
+
+// limit applies the quota to every request
+func (s *Server) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.options.Quota > 0 {
+			// The request consuming the last unit succeeds, only the following ones are refused
+			s.mu.Lock()
+			exhausted := s.quota == 0
+			if !exhausted {
+				s.quota--
+			}
+			remaining := s.quota
+			s.mu.Unlock()
+
+			w.Header().Set("X-Rate-Limit-Remaining", fmt.Sprint(remaining))
+			if exhausted {
+				writeError(w, r, http.StatusTooManyRequests, "quota exhausted")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// etagOf returns the ETag of an encoded response body
+func etagOf(b []byte) string {
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// matchesETag reports whether the item matches the 'If-Match' header, if any
+func matchesETag(r *http.Request, item map[string]any) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if len(ifMatch) == 0 || ifMatch == "*" {
+		return true
+	}
+
+	b, err := json.Marshal(item)
+	return err == nil && ifMatch == etagOf(b)
+}
+
+// writeJSON writes the value using an etag, answering '304 Not Modified' if it matches 'If-None-Match'
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	etag := etagOf(b)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag)
+
+	if r.Method == http.MethodGet && r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// writeError writes a hawapi.ErrorResponse body
+func writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(hawapi.ErrorResponse{
+		Code:    status,
+		Status:  statusName(status),
+		Method:  r.Method,
+		Cause:   http.StatusText(status),
+		Url:     r.URL.Path,
+		Message: message,
+	})
+}
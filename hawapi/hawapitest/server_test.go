@@ -0,0 +1,449 @@
+package hawapitest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+	"github.com/google/uuid"
+)
+
+func newTestClient(srv *Server, token string) hawapi.Client {
+	return hawapi.NewClientWithOpts(hawapi.Options{
+		Endpoint:   srv.Endpoint(),
+		Token:      token,
+		LogHandler: hawapi.NewFormattedHandler(io.Discard, nil),
+		Retry:      hawapi.RetryPolicy{MaxAttempts: 1},
+	})
+}
+
+func TestServer_list(t *testing.T) {
+	srv := NewServer(Options{})
+	defer srv.Close()
+
+	for _, name := range []string{"C", "A", "E", "B", "D"} {
+		srv.Seed(hawapi.Actor{FirstName: name, LastName: "Ipsum"})
+	}
+
+	c := newTestClient(srv, "")
+
+	res, err := c.ListActors(hawapi.WithSize(2), hawapi.WithSort("first_name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.PageTotal != 3 || res.ItemSize != 5 || res.NextPage != 2 {
+		t.Errorf("ListActors() headers = %+v", res.HeaderResponse)
+	}
+
+	if len(res.Data) != 2 || res.Data[0].FirstName != "A" || res.Data[1].FirstName != "B" {
+		t.Errorf("ListActors() data = %+v", res.Data)
+	}
+
+	var names string
+	for actor, err := range c.AllActors(context.Background(), hawapi.WithSize(2), hawapi.WithSort("first_name"), hawapi.WithOrder("DESC")) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		names += actor.FirstName
+	}
+
+	if names != "EDCBA" {
+		t.Errorf("AllActors() = %v, want %v", names, "EDCBA")
+	}
+
+	filtered, err := c.ListActors(hawapi.WithFilter("first_name", "D"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(filtered.Data) != 1 || filtered.Data[0].FirstName != "D" {
+		t.Errorf("ListActors() filtered = %+v", filtered.Data)
+	}
+}
+
+func TestServer_language(t *testing.T) {
+	srv := NewServer(Options{})
+	defer srv.Close()
+
+	srv.Seed(
+		hawapi.Episode{Title: "Lorem", Language: "en-US"},
+		hawapi.Episode{Title: "Ipsum", Language: "pt-BR"},
+	)
+
+	c := newTestClient(srv, "")
+
+	res, err := c.ListEpisodes(hawapi.WithLanguage("pt-BR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Data) != 1 || res.Data[0].Title != "Ipsum" || res.Language != "pt-BR" {
+		t.Errorf("ListEpisodes() = %+v", res)
+	}
+}
+
+func TestServer_crud(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	c := newTestClient(srv, "token")
+
+	season, err := c.CreateSeason(hawapi.CreateSeason{Title: "Lorem", SeasonNum: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := c.FindSeason(season.Uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Data.Title != "Lorem" || found.Data.Href == "" {
+		t.Errorf("FindSeason() = %+v", found.Data)
+	}
+
+	random, err := c.RandomSeason()
+	if err != nil || random.Data.Uuid != season.Uuid {
+		t.Errorf("RandomSeason() = %+v, %v", random.Data, err)
+	}
+
+	if err := c.DeleteSeason(season.Uuid); err != nil {
+		t.Fatal(err)
+	}
+
+	if srv.Len("seasons") != 0 {
+		t.Errorf("Len() = %v, want %v", srv.Len("seasons"), 0)
+	}
+
+	overview, err := c.Overview()
+	if err != nil || overview.DataCount.Seasons != 0 {
+		t.Errorf("Overview() = %+v, %v", overview, err)
+	}
+}
+
+func TestServer_authorization(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:    "should require a token",
+			token:   "",
+			wantErr: hawapi.ErrTokenRequired,
+		},
+		{
+			name:    "should reject an invalid token",
+			token:   "invalid",
+			wantErr: hawapi.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(srv, tt.token)
+
+			_, err := c.CreateActor(hawapi.CreateActor{FirstName: "Lorem"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateActor() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServer_quota(t *testing.T) {
+	srv := NewServer(Options{Quota: 2})
+	defer srv.Close()
+
+	c := newTestClient(srv, "")
+
+	if _, err := c.Info(); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Quota().Remaining != 1 {
+		t.Errorf("Quota() = %v, want %v", c.Quota().Remaining, 1)
+	}
+
+	// The last unit of the quota can be used
+	if _, err := c.Info(); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Quota().Remaining != 0 {
+		t.Errorf("Quota() = %v, want %v", c.Quota().Remaining, 0)
+	}
+
+	_, err := c.Info()
+
+	if !errors.Is(err, hawapi.ErrRateLimited) {
+		t.Errorf("Info() error = %v, want %v", err, hawapi.ErrRateLimited)
+	}
+}
+
+func TestServer_auth(t *testing.T) {
+	srv := NewServer(Options{Token: "secret"})
+	defer srv.Close()
+
+	c := hawapi.NewClientWithOpts(hawapi.Options{
+		Endpoint:       srv.Endpoint(),
+		LogHandler:     hawapi.NewFormattedHandler(io.Discard, nil),
+		Retry:          hawapi.RetryPolicy{MaxAttempts: 1},
+		AdoptAuthToken: true,
+	})
+
+	ctx := context.Background()
+	_, err := c.CreateActorContext(ctx, hawapi.CreateActor{FirstName: "Lorem"})
+	if !errors.Is(err, hawapi.ErrTokenRequired) {
+		t.Fatalf("CreateActor() error = %v, want %v", err, hawapi.ErrTokenRequired)
+	}
+
+	registered, err := c.Register(ctx, hawapi.RegisterRequest{
+		Username: "lorem",
+		Email:    "lorem@ipsum.com",
+		Password: "password",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if registered.Username != "lorem" || registered.Token != "secret" {
+		t.Errorf("Register() = %+v", registered)
+	}
+
+	_, err = c.Register(ctx, hawapi.RegisterRequest{Username: "lorem", Email: "lorem@ipsum.com", Password: "password"})
+	var resErr hawapi.ErrorResponse
+	if !errors.As(err, &resErr) || resErr.Code != 409 {
+		t.Errorf("Register() error = %v, want a 409 ErrorResponse", err)
+	}
+
+	// The token returned by Register is adopted
+	if _, err := c.CreateActorContext(ctx, hawapi.CreateActor{FirstName: "Lorem"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Authenticate(ctx, hawapi.Credentials{Email: "lorem@ipsum.com", Password: "wrong"})
+	if !errors.Is(err, hawapi.ErrUnauthorized) {
+		t.Errorf("Authenticate() error = %v, want %v", err, hawapi.ErrUnauthorized)
+	}
+
+	authenticated, err := c.Authenticate(ctx, hawapi.Credentials{Email: "lorem@ipsum.com", Password: "password"})
+	if err != nil || authenticated.Uuid != registered.Uuid {
+		t.Errorf("Authenticate() = %+v, %v", authenticated, err)
+	}
+}
+
+func TestServer_patch(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	id := uuid.New()
+	srv.Seed(hawapi.Actor{UUID: id, FirstName: "Lorem", LastName: "Ipsum", DeathDate: "2020-01-01", Nicknames: []string{"Dolor"}})
+
+	c := newTestClient(srv, "token")
+
+	actor, err := c.PatchActor(id, hawapi.PatchActor{
+		FirstName: hawapi.Set("Sit"),
+		DeathDate: hawapi.Null[string](),
+		Nicknames: hawapi.Null[[]string](),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actor.FirstName != "Sit" || actor.LastName != "Ipsum" || actor.DeathDate != "" || actor.Nicknames != nil {
+		t.Errorf("PatchActor() = %+v", actor)
+	}
+}
+
+func TestServer_ifMatch(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	id := uuid.New()
+	srv.Seed(hawapi.Actor{UUID: id, FirstName: "Lorem"})
+
+	ctx := context.Background()
+	editor := newTestClient(srv, "token")
+	other := newTestClient(srv, "token")
+
+	res, err := editor.FindActor(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := other.PatchActorContext(ctx, id, hawapi.PatchActor{FirstName: hawapi.Set("Ipsum")}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = editor.PatchActorContext(ctx, id, hawapi.PatchActor{FirstName: hawapi.Set("Dolor")}, hawapi.WithIfMatch(res.Etag))
+	if !errors.Is(err, hawapi.ErrPreconditionFailed) {
+		t.Errorf("PatchActor() error = %v, want %v", err, hawapi.ErrPreconditionFailed)
+	}
+
+	err = editor.DeleteActorContext(ctx, id, hawapi.WithIfMatch(res.Etag))
+	if !errors.Is(err, hawapi.ErrPreconditionFailed) {
+		t.Errorf("DeleteActor() error = %v, want %v", err, hawapi.ErrPreconditionFailed)
+	}
+
+	res, err = editor.FindActor(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := editor.DeleteActorContext(ctx, id, hawapi.WithIfMatch(res.Etag)); err != nil {
+		t.Errorf("DeleteActor() error = %v", err)
+	}
+}
+
+func TestServer_ifMatchCached(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	id := uuid.New()
+	srv.Seed(hawapi.Actor{UUID: id, FirstName: "Lorem"})
+
+	editor := newTestClient(srv, "token")
+	editor.WithOpts(hawapi.Options{UseInMemoryCache: true})
+	other := newTestClient(srv, "token")
+
+	if _, err := editor.FindActor(id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := other.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Ipsum")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ETag of the cached item is used
+	_, err := editor.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Dolor")})
+	if !errors.Is(err, hawapi.ErrPreconditionFailed) {
+		t.Errorf("PatchActor() error = %v, want %v", err, hawapi.ErrPreconditionFailed)
+	}
+}
+
+func TestServer_ifMatchConflict(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	id := uuid.New()
+	srv.Seed(hawapi.Actor{UUID: id, FirstName: "Lorem"})
+
+	editor := newTestClient(srv, "token")
+	editor.WithOpts(hawapi.Options{UseInMemoryCache: true})
+	other := newTestClient(srv, "token")
+
+	if _, err := editor.FindActor(id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := other.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Ipsum")}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := editor.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Dolor")})
+	if !errors.Is(err, hawapi.ErrPreconditionFailed) {
+		t.Fatalf("PatchActor() error = %v, want %v", err, hawapi.ErrPreconditionFailed)
+	}
+
+	// The rejected item is no longer cached, so it can be read again and patched
+	res, err := editor.FindActor(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Data.FirstName != "Ipsum" {
+		t.Errorf("FindActor() = %+v, want the patched actor", res.Data)
+	}
+
+	actor, err := editor.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Dolor")})
+	if err != nil || actor.FirstName != "Dolor" {
+		t.Errorf("PatchActor() = %+v, %v", actor, err)
+	}
+}
+
+func TestServer_ifMatchPatched(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	id := uuid.New()
+	srv.Seed(hawapi.Actor{UUID: id, FirstName: "Lorem"})
+
+	editor := newTestClient(srv, "token")
+	editor.WithOpts(hawapi.Options{UseInMemoryCache: true})
+	other := newTestClient(srv, "token")
+
+	if _, err := editor.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Ipsum")}, hawapi.WithStrictIfMatch()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := other.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Dolor")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ETag of the patched item is used
+	_, err := editor.PatchActor(id, hawapi.PatchActor{FirstName: hawapi.Set("Sit")})
+	if !errors.Is(err, hawapi.ErrPreconditionFailed) {
+		t.Errorf("PatchActor() error = %v, want %v", err, hawapi.ErrPreconditionFailed)
+	}
+}
+
+func TestServer_cacheInvalidation(t *testing.T) {
+	srv := NewServer(Options{Token: "token"})
+	defer srv.Close()
+
+	id, other := uuid.New(), uuid.New()
+	srv.Seed(hawapi.Actor{UUID: id, FirstName: "Lorem"}, hawapi.Actor{UUID: other, FirstName: "Ipsum"})
+
+	c := newTestClient(srv, "token")
+	c.WithOpts(hawapi.Options{UseInMemoryCache: true})
+
+	fill := func() {
+		t.Helper()
+		for _, query := range [][]hawapi.QueryOptions{nil, {hawapi.WithPage(2), hawapi.WithSize(1)}} {
+			if _, err := c.ListActors(query...); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, actor := range []uuid.UUID{id, other} {
+			if _, err := c.FindActor(actor); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	fill()
+	if _, err := c.CreateActor(hawapi.CreateActor{FirstName: "Dolor"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the items are still cached
+	if got := c.CacheSize(); got != 2 {
+		t.Errorf("CacheSize() = %v, want %v", got, 2)
+	}
+
+	res, err := c.ListActors()
+	if err != nil || len(res.Data) != 3 {
+		t.Errorf("ListActors() = %v, %v, want 3 actors", res.Data, err)
+	}
+
+	fill()
+	if err := c.DeleteActor(id); err != nil {
+		t.Fatal(err)
+	}
+
+	// The other item is still cached
+	if got := c.CacheSize(); got != 1 {
+		t.Errorf("CacheSize() = %v, want %v", got, 1)
+	}
+
+	if _, err := c.FindActor(id); !errors.Is(err, hawapi.ErrNotFound) {
+		t.Errorf("FindActor() error = %v, want %v", err, hawapi.ErrNotFound)
+	}
+}
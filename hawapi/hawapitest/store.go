@@ -0,0 +1,116 @@
+package hawapitest
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// store keeps the items of a resource as JSON objects, in insertion order
+type store struct {
+	items map[uuid.UUID]map[string]any
+	order []uuid.UUID
+}
+
+func newStore() *store {
+	return &store{
+		items: make(map[uuid.UUID]map[string]any),
+	}
+}
+
+func (s *store) get(id uuid.UUID) (map[string]any, bool) {
+	item, ok := s.items[id]
+	return item, ok
+}
+
+func (s *store) put(id uuid.UUID, item map[string]any) {
+	if _, ok := s.items[id]; !ok {
+		s.order = append(s.order, id)
+	}
+	s.items[id] = item
+}
+
+func (s *store) delete(id uuid.UUID) bool {
+	if _, ok := s.items[id]; !ok {
+		return false
+	}
+
+	delete(s.items, id)
+	s.order = slices.DeleteFunc(s.order, func(v uuid.UUID) bool { return v == id })
+	return true
+}
+
+// filter returns the items matching the language and all filters
+func (s *store) filter(language string, filters map[string]string) []map[string]any {
+	var items []map[string]any
+
+	for _, id := range s.order {
+		item := s.items[id]
+
+		// Items without language are available in all languages
+		if lang, ok := item["language"].(string); ok && len(lang) != 0 && lang != language {
+			continue
+		}
+
+		if matches(item, filters) {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func matches(item map[string]any, filters map[string]string) bool {
+	for key, want := range filters {
+		switch v := item[key].(type) {
+		case []any:
+			if !slices.ContainsFunc(v, func(e any) bool { return fmt.Sprint(e) == want }) {
+				return false
+			}
+		default:
+			if v == nil || !strings.EqualFold(fmt.Sprint(v), want) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sortItems sorts the items using a 'field' or 'field,ORDER' value
+func sortItems(items []map[string]any, sort string) {
+	field, order, _ := strings.Cut(sort, ",")
+	desc := strings.EqualFold(order, "DESC")
+
+	slices.SortStableFunc(items, func(a, b map[string]any) int {
+		c := compareValues(a[field], b[field])
+		if desc {
+			return -c
+		}
+		return c
+	})
+}
+
+func compareValues(a, b any) int {
+	if fa, ok := a.(float64); ok {
+		if fb, ok := b.(float64); ok {
+			return cmp.Compare(fa, fb)
+		}
+	}
+
+	if a == nil || b == nil {
+		// Missing values go last
+		return cmp.Compare(boolInt(a == nil), boolInt(b == nil))
+	}
+
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -1,6 +1,9 @@
 package hawapi
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 type Info struct {
 	Title       string `json:"title"`
@@ -19,9 +22,13 @@ type Info struct {
 }
 
 func (c *Client) Info() (Info, error) {
+	return c.InfoContext(context.Background())
+}
+
+func (c *Client) InfoContext(ctx context.Context) (Info, error) {
 	var info Info
 
-	req, err := http.NewRequest(http.MethodGet, c.options.Endpoint, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.options.Endpoint, nil)
 	if err != nil {
 		return info, err
 	}
@@ -1,6 +1,9 @@
 package hawapi
 
 import (
+	"context"
+	"iter"
+
 	"github.com/google/uuid"
 )
 
@@ -28,99 +31,78 @@ type CreateLocation struct {
 	Sources     []string `json:"sources,omitempty"`
 }
 
-type PatchLocation = CreateLocation
-
-type LocationResponse struct {
-	BaseResponse
-	Data Location `json:"data"`
+// PatchLocation is a JSON Merge Patch, only its set fields are updated
+type PatchLocation struct {
+	Name        Optional[string]   `json:"name,omitzero"`
+	Description Optional[string]   `json:"description,omitzero"`
+	Language    Optional[string]   `json:"language,omitzero"`
+	Thumbnail   Optional[string]   `json:"thumbnail,omitzero"`
+	Images      Optional[[]string] `json:"images,omitzero"`
+	Sources     Optional[[]string] `json:"sources,omitzero"`
 }
 
-type LocationListResponse struct {
-	BaseResponse
-	Data []Location `json:"data"`
+type LocationResponse = ItemResponse[Location]
+
+type LocationListResponse = ListResponse[Location]
+
+// Locations returns the typed client for locations
+func (c *Client) Locations() Resource[Location, CreateLocation, PatchLocation] {
+	return NewResource[Location, CreateLocation, PatchLocation](c, locationOrigin)
 }
 
 // ListLocations will get all locations
 func (c *Client) ListLocations(options ...QueryOptions) (LocationListResponse, error) {
-	var locations []Location
-	var res LocationListResponse
-
-	doRes, err := c.doGetRequest(locationOrigin, options, &locations)
-	if err != nil {
-		return res, err
-	}
+	return c.Locations().List(context.Background(), options...)
+}
 
-	res = LocationListResponse{
-		BaseResponse: doRes,
-		Data:         locations,
-	}
+// ListLocationsContext will get all locations using the given context
+func (c *Client) ListLocationsContext(ctx context.Context, options ...QueryOptions) (LocationListResponse, error) {
+	return c.Locations().List(ctx, options...)
+}
 
-	return res, nil
+// AllLocations iterates over all locations of every page, starting at the page defined by the options
+func (c *Client) AllLocations(ctx context.Context, options ...QueryOptions) iter.Seq2[Location, error] {
+	return c.Locations().All(ctx, options...)
 }
 
 // FindLocation will get a single item by uuid
 func (c *Client) FindLocation(id uuid.UUID) (LocationResponse, error) {
-	var location Location
-	var res LocationResponse
-
-	doRes, err := c.doGetRequest(locationOrigin+"/"+id.String(), nil, &location)
-	if err != nil {
-		return res, err
-	}
-
-	res = LocationResponse{
-		BaseResponse: doRes,
-		Data:         location,
-	}
+	return c.Locations().Find(context.Background(), id)
+}
 
-	return res, nil
+// FindLocationContext will get a single item by uuid using the given context
+func (c *Client) FindLocationContext(ctx context.Context, id uuid.UUID) (LocationResponse, error) {
+	return c.Locations().Find(ctx, id)
 }
 
 func (c *Client) RandomLocation() (LocationResponse, error) {
-	var location Location
-	var res LocationResponse
-
-	doRes, err := c.doGetRequest(locationOrigin+"/random", nil, &location)
-	if err != nil {
-		return res, err
-	}
-
-	res = LocationResponse{
-		BaseResponse: doRes,
-		Data:         location,
-	}
+	return c.Locations().Random(context.Background())
+}
 
-	return res, nil
+func (c *Client) RandomLocationContext(ctx context.Context) (LocationResponse, error) {
+	return c.Locations().Random(ctx)
 }
 
 func (c *Client) CreateLocation(s CreateLocation) (Location, error) {
-	var location Location
-
-	err := c.doPostRequest(locationOrigin, s, &location)
-	if err != nil {
-		return location, err
-	}
-
-	return location, nil
+	return c.Locations().Create(context.Background(), s)
 }
 
-func (c *Client) PatchLocation(id uuid.UUID, p PatchLocation) (Location, error) {
-	var location Location
+func (c *Client) CreateLocationContext(ctx context.Context, s CreateLocation) (Location, error) {
+	return c.Locations().Create(ctx, s)
+}
 
-	err := c.doPatchRequest(locationOrigin+"/"+id.String(), &p)
-	if err != nil {
-		return location, err
-	}
+func (c *Client) PatchLocation(id uuid.UUID, p PatchLocation, options ...WriteOptions) (Location, error) {
+	return c.Locations().Patch(context.Background(), id, p, options...)
+}
 
-	res, err := c.FindLocation(id)
-	if err != nil {
-		return location, err
-	}
+func (c *Client) PatchLocationContext(ctx context.Context, id uuid.UUID, p PatchLocation, options ...WriteOptions) (Location, error) {
+	return c.Locations().Patch(ctx, id, p, options...)
+}
 
-	location = res.Data
-	return location, nil
+func (c *Client) DeleteLocation(id uuid.UUID, options ...WriteOptions) error {
+	return c.Locations().Delete(context.Background(), id, options...)
 }
 
-func (c *Client) DeleteLocation(id uuid.UUID) error {
-	return c.doDeleteRequest(locationOrigin + "/" + id.String())
+func (c *Client) DeleteLocationContext(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return c.Locations().Delete(ctx, id, options...)
 }
@@ -0,0 +1,31 @@
+package hawapi
+
+import "net/http"
+
+// Doer sends a single request, *http.Client is the default implementation
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is a function implementing Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to add behaviour around requests (headers, logging, metrics...)
+//
+// A middleware is called for every attempt of a request, including retries.
+type Middleware func(next Doer) Doer
+
+// chain wraps the doer with the middlewares, the first middleware being the outermost.
+//
+// So with [a, b], a request goes through a, b, doer and the response through b then a.
+func chain(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
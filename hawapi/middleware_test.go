@@ -0,0 +1,104 @@
+package hawapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name+" request")
+			req.Header.Add("X-Middleware", name)
+
+			res, err := next.Do(req)
+
+			*calls = append(*calls, name+" response")
+			return res, err
+		})
+	}
+}
+
+func TestClient_middlewares(t *testing.T) {
+	var gotHeaders [][]string
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = append(gotHeaders, r.Header.Values("X-Middleware"))
+
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case r.URL.Path == "/v1/actors":
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer sv.Close()
+
+	var calls []string
+	c := NewClientWithOpts(Options{
+		Endpoint:   sv.URL,
+		Token:      "token",
+		LogHandler: defaultTestLoggerHandler,
+		Middlewares: []Middleware{
+			recordingMiddleware("a", &calls),
+			recordingMiddleware("b", &calls),
+		},
+	})
+
+	ctx := context.Background()
+	requests := []func() error{
+		func() error { _, err := c.InfoContext(ctx); return err },
+		func() error { _, err := c.ListActorsContext(ctx); return err },
+		func() error { _, err := c.CreateActorContext(ctx, CreateActor{}); return err },
+		func() error { return c.DeleteActorContext(ctx, uuid.New()) },
+	}
+
+	for _, request := range requests {
+		calls = nil
+		if err := request(); err != nil {
+			t.Fatal(err)
+		}
+
+		wantCalls := []string{"a request", "b request", "b response", "a response"}
+		if !reflect.DeepEqual(calls, wantCalls) {
+			t.Errorf("calls = %v, want %v", calls, wantCalls)
+		}
+	}
+
+	for i, headers := range gotHeaders {
+		if !reflect.DeepEqual(headers, []string{"a", "b"}) {
+			t.Errorf("request[%d] headers = %v, want %v", i, headers, []string{"a", "b"})
+		}
+	}
+}
+
+func TestClient_middlewaresShortCircuit(t *testing.T) {
+	errBlocked := errors.New("blocked")
+
+	c := NewClientWithOpts(Options{
+		Endpoint:   "http://localhost:0",
+		LogHandler: defaultTestLoggerHandler,
+		Retry:      RetryPolicy{MaxAttempts: 1},
+		Middlewares: []Middleware{
+			func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					return nil, errBlocked
+				})
+			},
+		},
+	})
+
+	if _, err := c.Info(); !errors.Is(err, errBlocked) {
+		t.Errorf("Info() error = %v, want %v", err, errBlocked)
+	}
+}
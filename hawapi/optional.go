@@ -0,0 +1,73 @@
+package hawapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional is a patch field, which can be unset (the zero value), set to a value or set to null.
+//
+// Used with the 'omitzero' json option, unset fields are omitted from the JSON Merge Patch (RFC 7396),
+// and null fields clear the value of the item.
+//
+//	patch := hawapi.PatchActor{
+//		FirstName: hawapi.Set("Lorem"),
+//		DeathDate: hawapi.Null[string](),
+//	}
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// Set returns an Optional set to the value
+func Set[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Null returns an Optional set to null, clearing the field
+func Null[T any]() Optional[T] {
+	return Optional[T]{set: true, null: true}
+}
+
+// Get returns the value, and whether it is set to a value (not unset nor null)
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set && !o.null
+}
+
+// IsSet reports whether the Optional is set, to a value or null
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsNull reports whether the Optional is set to null
+func (o Optional[T]) IsNull() bool {
+	return o.null
+}
+
+// IsZero reports whether the Optional is unset, used by the 'omitzero' json option
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set || o.null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Null[T]()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*o = Set(value)
+	return nil
+}
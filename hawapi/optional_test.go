@@ -0,0 +1,66 @@
+package hawapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestOptional_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch PatchActor
+		want  string
+	}{
+		{
+			name:  "should omit unset fields",
+			patch: PatchActor{},
+			want:  `{}`,
+		},
+		{
+			name:  "should set fields",
+			patch: PatchActor{FirstName: Set("Lorem"), Gender: Set(0)},
+			want:  `{"first_name":"Lorem","gender":0}`,
+		},
+		{
+			name:  "should set null fields",
+			patch: PatchActor{DeathDate: Null[string](), Nicknames: Null[[]string]()},
+			want:  `{"nicknames":null,"death_date":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.patch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptional_UnmarshalJSON(t *testing.T) {
+	var patch PatchActor
+	if err := json.Unmarshal([]byte(`{"first_name":"Lorem","death_date":null,"nicknames":["Ipsum"]}`), &patch); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := patch.FirstName.Get(); !ok || got != "Lorem" {
+		t.Errorf("FirstName = %v, %v, want Lorem", got, ok)
+	}
+
+	if !patch.DeathDate.IsSet() || !patch.DeathDate.IsNull() {
+		t.Errorf("DeathDate should be null")
+	}
+
+	if got, _ := patch.Nicknames.Get(); !reflect.DeepEqual(got, []string{"Ipsum"}) {
+		t.Errorf("Nicknames = %v, want %v", got, []string{"Ipsum"})
+	}
+
+	if patch.LastName.IsSet() {
+		t.Errorf("LastName should be unset")
+	}
+}
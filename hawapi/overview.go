@@ -1,5 +1,7 @@
 package hawapi
 
+import "context"
+
 type DataCount struct {
 	Actors      int `json:"actors"`
 	Characters  int `json:"characters"`
@@ -26,9 +28,13 @@ type Overview struct {
 }
 
 func (c *Client) Overview(options ...QueryOptions) (Overview, error) {
+	return c.OverviewContext(context.Background(), options...)
+}
+
+func (c *Client) OverviewContext(ctx context.Context, options ...QueryOptions) (Overview, error) {
 	var overview Overview
 
-	_, err := c.doGetRequest("overview", options, &overview)
+	_, err := c.doGetRequest(ctx, "overview", options, &overview)
 	if err != nil {
 		return overview, err
 	}
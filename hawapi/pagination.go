@@ -0,0 +1,40 @@
+package hawapi
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// listPageFunc fetches a single page of items
+type listPageFunc[T any] func(ctx context.Context, options ...QueryOptions) ([]T, BaseResponse, error)
+
+// paginate iterates over the items of every page, starting at the page defined by the options.
+//
+// Pages are fetched lazily and the iteration stops at the first error.
+func paginate[T any](ctx context.Context, options []QueryOptions, list listPageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pageOptions := options
+
+		for {
+			items, res, err := list(ctx, pageOptions...)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) == 0 || res.NextPage == -1 || (res.PageTotal > 0 && res.Page >= res.PageTotal) {
+				return
+			}
+
+			pageOptions = append(slices.Clip(options), WithPage(res.NextPage))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package hawapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_AllActors(t *testing.T) {
+	const pageTotal = 3
+
+	var requests atomic.Int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+
+		w.Header().Set(apiHeaderPageIndex, strconv.Itoa(page))
+		w.Header().Set(apiHeaderPageTotal, strconv.Itoa(pageTotal))
+		fmt.Fprintf(w, `[{"first_name": "Page %d", "last_name": "A"}, {"first_name": "Page %d", "last_name": "B"}]`, page, page)
+	}))
+	defer sv.Close()
+
+	tests := []struct {
+		name         string
+		options      []QueryOptions
+		limit        int
+		wantItems    int
+		wantRequests int
+		wantFirst    string
+	}{
+		{
+			name:         "should iterate over all pages",
+			wantItems:    6,
+			wantRequests: 3,
+			wantFirst:    "Page 1",
+		},
+		{
+			name:         "should start at the given page",
+			options:      []QueryOptions{WithPage(2)},
+			wantItems:    4,
+			wantRequests: 2,
+			wantFirst:    "Page 2",
+		},
+		{
+			name:         "should stop fetching when the loop breaks",
+			limit:        3,
+			wantItems:    3,
+			wantRequests: 2,
+			wantFirst:    "Page 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			c := NewClientWithOpts(Options{
+				Endpoint:   sv.URL,
+				LogHandler: defaultTestLoggerHandler,
+			})
+
+			var actors []Actor
+			for actor, err := range c.AllActors(context.Background(), tt.options...) {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				actors = append(actors, actor)
+				if len(actors) == tt.limit {
+					break
+				}
+			}
+
+			if len(actors) != tt.wantItems {
+				t.Errorf("AllActors() items = %v, want %v", len(actors), tt.wantItems)
+			}
+
+			if got := int(requests.Load()); got != tt.wantRequests {
+				t.Errorf("AllActors() requests = %v, want %v", got, tt.wantRequests)
+			}
+
+			if actors[0].FirstName != tt.wantFirst {
+				t.Errorf("AllActors() first = %v, want %v", actors[0].FirstName, tt.wantFirst)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package hawapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultQuotaWait is how long the client blocks (QuotaBlock) or fails (QuotaFailFast) without a custom wait
+const DefaultQuotaWait = time.Minute
+
+// QuotaMode defines what the client does when the remaining quota reaches the floor
+type QuotaMode int
+
+const (
+	// QuotaIgnore keeps sending requests, regardless of the remaining quota
+	QuotaIgnore QuotaMode = iota
+
+	// QuotaFailFast fails with ErrQuotaExhausted without sending the request,
+	// until QuotaPolicy.Wait elapsed since the quota was reported
+	QuotaFailFast
+
+	// QuotaBlock waits before sending the request
+	QuotaBlock
+)
+
+// QuotaPolicy defines how the client handles the 'X-Rate-Limit-Remaining' quota
+type QuotaPolicy struct {
+	// What the client does when the remaining quota reaches the Floor
+	//
+	// Default value: QuotaIgnore
+	Mode QuotaMode
+
+	// The remaining quota at (or below) which the Mode is applied
+	Floor int
+
+	// How long to block before sending the request when using QuotaBlock.
+	// When using QuotaFailFast, how long requests fail before one is sent to check if the quota was renewed
+	//
+	// Default value: DefaultQuotaWait
+	Wait time.Duration
+}
+
+// quotaTracker keeps the last known remaining quota, shared by all requests
+type quotaTracker struct {
+	remaining atomic.Int64
+
+	// When the remaining quota was last reported, in unix nanoseconds
+	updated atomic.Int64
+}
+
+func newQuotaTracker() *quotaTracker {
+	q := &quotaTracker{}
+	q.remaining.Store(-1)
+	return q
+}
+
+// load returns the remaining quota, or -1 if unknown
+func (q *quotaTracker) load() int {
+	if q == nil {
+		return -1
+	}
+	return int(q.remaining.Load())
+}
+
+// update stores the remaining quota reported by the response
+func (q *quotaTracker) update(res *http.Response) {
+	if q == nil {
+		return
+	}
+
+	remaining := parseInt(res.Header.Get(apiHeaderRateLimitRemaining))
+	if remaining < 0 && res.StatusCode == http.StatusTooManyRequests {
+		remaining = 0
+	}
+
+	if remaining >= 0 {
+		q.remaining.Store(int64(remaining))
+		q.updated.Store(time.Now().UnixNano())
+	}
+}
+
+// since returns how long ago the remaining quota was reported
+func (q *quotaTracker) since() time.Duration {
+	return time.Since(time.Unix(0, q.updated.Load()))
+}
+
+// reset forgets the remaining quota until the next response
+func (q *quotaTracker) reset() {
+	if q != nil {
+		q.remaining.Store(-1)
+	}
+}
+
+// Quota returns the last known quota, the remaining value is -1 until a response reports it
+func (c *Client) Quota() Quota {
+	return Quota{Remaining: c.quota.load()}
+}
+
+// checkQuota applies the QuotaPolicy before sending a request
+func (c *Client) checkQuota(ctx context.Context) error {
+	policy := c.options.Quota
+	if policy.Mode == QuotaIgnore {
+		return nil
+	}
+
+	remaining := c.quota.load()
+	if remaining < 0 || remaining > policy.Floor {
+		return nil
+	}
+
+	wait := policy.Wait
+	if wait <= 0 {
+		wait = DefaultQuotaWait
+	}
+
+	if policy.Mode == QuotaFailFast {
+		if c.quota.since() < wait {
+			return fmt.Errorf("%w: %d requests remaining", ErrQuotaExhausted, remaining)
+		}
+
+		// The quota may have been renewed, so this request is sent to check it
+		c.logger.Debug("quota reached the floor a while ago, checking if it was renewed")
+		c.quota.reset()
+		return nil
+	}
+
+	c.logger.Warn(fmt.Sprintf("quota reached the floor (%d requests remaining), waiting %s", remaining, wait))
+	if err := sleep(ctx, wait); err != nil {
+		return err
+	}
+
+	// The next response will report the renewed quota
+	c.quota.reset()
+	return nil
+}
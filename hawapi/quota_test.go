@@ -0,0 +1,127 @@
+package hawapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_checkQuota(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       QuotaPolicy
+		wantRequests int
+		wantErr      error
+	}{
+		{
+			name:         "should keep sending requests by default",
+			policy:       QuotaPolicy{},
+			wantRequests: 2,
+		},
+		{
+			name:         "should fail fast when the floor is reached",
+			policy:       QuotaPolicy{Mode: QuotaFailFast, Floor: 1},
+			wantRequests: 1,
+			wantErr:      ErrQuotaExhausted,
+		},
+		{
+			name:         "should not fail if the floor is not reached",
+			policy:       QuotaPolicy{Mode: QuotaFailFast, Floor: 0},
+			wantRequests: 2,
+		},
+		{
+			name:         "should block and send the request when the floor is reached",
+			policy:       QuotaPolicy{Mode: QuotaBlock, Floor: 1, Wait: time.Millisecond},
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.Header().Set(apiHeaderRateLimitRemaining, "1")
+				w.Write([]byte(`{}`))
+			}))
+			defer sv.Close()
+
+			c := NewClientWithOpts(Options{
+				Endpoint:   sv.URL,
+				LogHandler: defaultTestLoggerHandler,
+				Quota:      tt.policy,
+			})
+
+			if c.Quota().Remaining != -1 {
+				t.Errorf("Quota() = %v, want unknown quota", c.Quota())
+			}
+
+			if _, err := c.InfoContext(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if c.Quota().Remaining != 1 {
+				t.Errorf("Quota() = %v, want %v", c.Quota().Remaining, 1)
+			}
+
+			_, err := c.InfoContext(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Info() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := int(requests.Load()); got != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestClient_checkQuota_renewed(t *testing.T) {
+	var requests atomic.Int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The quota is renewed after the first request
+		remaining := "100"
+		if requests.Add(1) == 1 {
+			remaining = "0"
+		}
+
+		w.Header().Set(apiHeaderRateLimitRemaining, remaining)
+		w.Write([]byte(`{}`))
+	}))
+	defer sv.Close()
+
+	c := NewClientWithOpts(Options{
+		Endpoint:   sv.URL,
+		LogHandler: defaultTestLoggerHandler,
+		Quota:      QuotaPolicy{Mode: QuotaFailFast, Wait: 20 * time.Millisecond},
+	})
+
+	ctx := context.Background()
+	if _, err := c.InfoContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.InfoContext(ctx); !errors.Is(err, ErrQuotaExhausted) {
+		t.Errorf("Info() error = %v, want %v", err, ErrQuotaExhausted)
+	}
+
+	// Once the wait elapsed, a request is sent to check the quota
+	time.Sleep(30 * time.Millisecond)
+
+	for range 2 {
+		if _, err := c.InfoContext(ctx); err != nil {
+			t.Errorf("Info() error = %v", err)
+		}
+	}
+
+	if c.Quota().Remaining != 100 {
+		t.Errorf("Quota() = %v, want %v", c.Quota().Remaining, 100)
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %v, want %v", got, 3)
+	}
+}
@@ -0,0 +1,287 @@
+// Package recorder provides an http.RoundTripper recording HawAPI interactions to a golden file,
+// and replaying them later without network.
+//
+//	rec, err := recorder.New(recorder.Options{
+//		Path: "testdata/actors.json",
+//		Mode: recorder.ModeReplay,
+//	})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Close()
+//
+//	client := hawapi.NewClientWithOpts(hawapi.Options{
+//		Transport: rec,
+//	})
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Redacted replaces the value of redacted headers
+const Redacted = "[REDACTED]"
+
+// ErrUnmatched is returned in replay mode when no recorded interaction matches the request
+//
+// The returned error isn't retried by the hawapi client, as it would fail again
+var ErrUnmatched = errors.New("no recorded interaction matches the request")
+
+// unmatchedError is the ErrUnmatched returned for a request
+type unmatchedError struct {
+	method string
+	url    string
+}
+
+func (e *unmatchedError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrUnmatched, e.method, e.url)
+}
+
+func (e *unmatchedError) Is(target error) bool {
+	return target == ErrUnmatched
+}
+
+// Retryable reports that the request can't be retried, used by the hawapi RetryPolicy
+func (e *unmatchedError) Retryable() bool {
+	return false
+}
+
+// Mode defines if the Recorder records or replays interactions
+type Mode int
+
+const (
+	// ModeReplay serves the recorded interactions, without network
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests and records the interactions
+	ModeRecord
+)
+
+type Options struct {
+	// The golden file path
+	Path string
+
+	Mode Mode
+
+	// The transport used to send requests in record mode
+	//
+	// Default value: http.DefaultTransport
+	Transport http.RoundTripper
+
+	// Headers which are recorded with a Redacted value, in addition to 'Authorization'
+	RedactHeaders []string
+}
+
+// Request is a recorded request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request / response pair
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Recorder is an http.RoundTripper recording or replaying interactions
+type Recorder struct {
+	options Options
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// New creates a Recorder. In replay mode, the golden file must exist.
+func New(options Options) (*Recorder, error) {
+	if len(options.Path) == 0 {
+		return nil, errors.New("recorder: path is required")
+	}
+
+	if options.Transport == nil {
+		options.Transport = http.DefaultTransport
+	}
+
+	r := &Recorder{options: options}
+	if options.Mode == ModeRecord {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &r.interactions); err != nil {
+		return nil, fmt.Errorf("recorder: invalid golden file '%s': %w", options.Path, err)
+	}
+
+	r.replayed = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// RoundTrip records or replays the request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if r.options.Mode == ModeRecord {
+		return r.record(req, body)
+	}
+
+	return r.replay(req, body)
+}
+
+// Interactions returns the recorded interactions
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.interactions)
+}
+
+// Close writes the golden file in record mode
+func (r *Recorder) Close() error {
+	if r.options.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.options.Path), 0o755); err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+
+	return os.WriteFile(r.options.Path, append(b, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	// The request can't be modified, so its body is sent using a clone
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	res, err := r.options.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: r.redact(req.Header),
+			Body:   string(body),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       string(resBody),
+		},
+	})
+	r.mu.Unlock()
+
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || !matches(interaction.Request, req, body) {
+			continue
+		}
+
+		r.replayed[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &unmatchedError{method: req.Method, url: req.URL.String()}
+}
+
+// redact copies the header, replacing the values of sensitive headers
+func (r *Recorder) redact(header http.Header) http.Header {
+	h := header.Clone()
+	for _, key := range append([]string{"Authorization"}, r.options.RedactHeaders...) {
+		if len(h.Values(key)) != 0 {
+			h.Set(key, Redacted)
+		}
+	}
+	return h
+}
+
+// matches reports whether the recorded request has the same method, url and body
+func matches(recorded Request, req *http.Request, body []byte) bool {
+	return recorded.Method == req.Method && recorded.URL == req.URL.String() && recorded.Body == string(body)
+}
+
+// readBody reads the request body, using a copy from GetBody if possible
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body := req.Body
+	if req.GetBody != nil {
+		b, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer b.Close()
+		body = b
+	}
+
+	return io.ReadAll(body)
+}
@@ -0,0 +1,148 @@
+package recorder
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/HawAPI/go-sdk/hawapi"
+)
+
+func newTestClient(endpoint string, rec *Recorder) hawapi.Client {
+	return hawapi.NewClientWithOpts(hawapi.Options{
+		Endpoint:   endpoint,
+		Token:      "secret",
+		Transport:  rec,
+		LogHandler: hawapi.NewFormattedHandler(io.Discard, nil),
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "actors.json")
+
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			io.Copy(w, r.Body)
+			return
+		}
+		w.Write([]byte(`[{"first_name": "Lorem", "last_name": "Ipsum"}]`))
+	}))
+
+	rec, err := New(Options{Path: path, Mode: ModeRecord})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestClient(sv.URL, rec)
+	if _, err := c.ListActors(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CreateActor(hawapi.CreateActor{FirstName: "Dolor"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	sv.Close()
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(golden), "secret") || !strings.Contains(string(golden), Redacted) {
+		t.Errorf("golden file should redact the token: %s", golden)
+	}
+
+	rec, err = New(Options{Path: path, Mode: ModeReplay})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = newTestClient(sv.URL, rec)
+
+	res, err := c.ListActors()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Data) != 1 || res.Data[0].FirstName != "Lorem" {
+		t.Errorf("ListActors() = %+v", res.Data)
+	}
+
+	actor, err := c.CreateActor(hawapi.CreateActor{FirstName: "Dolor"})
+	if err != nil || actor.FirstName != "Dolor" {
+		t.Errorf("CreateActor() = %+v, %v", actor, err)
+	}
+
+	// Each interaction is replayed once
+	_, err = c.CreateActor(hawapi.CreateActor{FirstName: "Dolor"})
+	if !errors.Is(err, ErrUnmatched) {
+		t.Errorf("CreateActor() error = %v, want %v", err, ErrUnmatched)
+	}
+
+	_, err = c.ListSeasons()
+	if !errors.Is(err, ErrUnmatched) {
+		t.Errorf("ListSeasons() error = %v, want %v", err, ErrUnmatched)
+	}
+
+	// Unmatched requests are not retried
+	var retryErr *hawapi.RetryError
+	if errors.As(err, &retryErr) {
+		t.Errorf("ListSeasons() error = %v, want no retry", err)
+	}
+}
+
+func TestRecorder_requestBody(t *testing.T) {
+	var got string
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got = string(b)
+	}))
+	defer sv.Close()
+
+	rec, err := New(Options{Path: filepath.Join(t.TempDir(), "body.json"), Mode: ModeRecord})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without GetBody, the body can only be read once
+	body := io.NopCloser(strings.NewReader(`{"first_name": "Lorem"}`))
+	req, err := http.NewRequest(http.MethodPost, sv.URL, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if req.Body != body {
+		t.Error("RoundTrip() should not modify the request")
+	}
+
+	if got != `{"first_name": "Lorem"}` {
+		t.Errorf("sent body = %q", got)
+	}
+
+	if interactions := rec.Interactions(); len(interactions) != 1 || interactions[0].Request.Body != got {
+		t.Errorf("Interactions() = %+v", interactions)
+	}
+}
+
+func TestNew_missingGoldenFile(t *testing.T) {
+	_, err := New(Options{Path: filepath.Join(t.TempDir(), "missing.json")})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("New() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
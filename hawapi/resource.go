@@ -0,0 +1,146 @@
+package hawapi
+
+import (
+	"context"
+	"iter"
+
+	"github.com/google/uuid"
+)
+
+// ItemResponse represents a single item response
+type ItemResponse[T any] struct {
+	BaseResponse
+	Data T `json:"data"`
+}
+
+// ListResponse represents a page of items response
+type ListResponse[T any] struct {
+	BaseResponse
+	Data []T `json:"data"`
+}
+
+// Resource is a typed client for a HawAPI resource.
+//
+//   - T is the model returned by the API
+//   - C is the model used to create a new item
+//   - P is the model used to patch an item
+type Resource[T, C, P any] struct {
+	client *Client
+	origin string
+}
+
+// NewResource creates a typed client for the resource available at origin (e.g. 'actors')
+func NewResource[T, C, P any](client *Client, origin string) Resource[T, C, P] {
+	return Resource[T, C, P]{
+		client: client,
+		origin: origin,
+	}
+}
+
+// Origin returns the resource origin
+func (r Resource[T, C, P]) Origin() string {
+	return r.origin
+}
+
+// List will get a page of items
+func (r Resource[T, C, P]) List(ctx context.Context, options ...QueryOptions) (ListResponse[T], error) {
+	var items []T
+	var res ListResponse[T]
+
+	doRes, err := r.client.doGetRequest(ctx, r.origin, options, &items)
+	if err != nil {
+		return res, err
+	}
+
+	res = ListResponse[T]{
+		BaseResponse: doRes,
+		Data:         items,
+	}
+
+	return res, nil
+}
+
+// All iterates over the items of every page, starting at the page defined by the options
+func (r Resource[T, C, P]) All(ctx context.Context, options ...QueryOptions) iter.Seq2[T, error] {
+	return paginate(ctx, options, func(ctx context.Context, options ...QueryOptions) ([]T, BaseResponse, error) {
+		res, err := r.List(ctx, options...)
+		return res.Data, res.BaseResponse, err
+	})
+}
+
+// Find will get a single item by uuid
+func (r Resource[T, C, P]) Find(ctx context.Context, id uuid.UUID) (ItemResponse[T], error) {
+	return r.get(ctx, r.itemOrigin(id))
+}
+
+// Random will get a random item
+func (r Resource[T, C, P]) Random(ctx context.Context) (ItemResponse[T], error) {
+	return r.get(ctx, r.origin+"/random")
+}
+
+// Create will create a new item
+func (r Resource[T, C, P]) Create(ctx context.Context, in C) (T, error) {
+	var item T
+
+	err := r.client.doPostRequest(ctx, r.origin, in, &item)
+	if err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+// Patch will update an item by uuid and return its new value
+//
+// The ETag of the cached item is sent using 'If-Match', and the patched item is cached with its new ETag.
+// Without cached item, no precondition is sent (see WithIfMatch and WithStrictIfMatch)
+func (r Resource[T, C, P]) Patch(ctx context.Context, id uuid.UUID, patch P, options ...WriteOptions) (T, error) {
+	var item T
+
+	decoded, err := r.client.doPatchRequest(ctx, r.itemOrigin(id), &patch, options, &item)
+	if err != nil {
+		return item, err
+	}
+
+	if decoded {
+		return item, nil
+	}
+
+	// The API didn't return the patched item, and its cached value was invalidated
+	res, err := r.Find(ctx, id)
+	if err != nil {
+		return item, err
+	}
+
+	item = res.Data
+	return item, nil
+}
+
+// Delete will delete an item by uuid
+//
+// The ETag of the cached item is sent using 'If-Match'.
+// Without cached item, no precondition is sent (see WithIfMatch and WithStrictIfMatch)
+func (r Resource[T, C, P]) Delete(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return r.client.doDeleteRequest(ctx, r.itemOrigin(id), options)
+}
+
+func (r Resource[T, C, P]) get(ctx context.Context, origin string) (ItemResponse[T], error) {
+	var item T
+	var res ItemResponse[T]
+
+	doRes, err := r.client.doGetRequest(ctx, origin, nil, &item)
+	if err != nil {
+		return res, err
+	}
+
+	res = ItemResponse[T]{
+		BaseResponse: doRes,
+		Data:         item,
+	}
+
+	return res, nil
+}
+
+func (r Resource[T, C, P]) itemOrigin(id uuid.UUID) string {
+	return r.origin + "/" + id.String()
+}
@@ -0,0 +1,223 @@
+package hawapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestResource(t *testing.T) {
+	type quote struct {
+		Text string `json:"text"`
+	}
+
+	id := uuid.New()
+	var gotPaths []string
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"text": "Lorem"}`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"text": "Ipsum"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer sv.Close()
+
+	c := NewClientWithOpts(Options{
+		Endpoint:   sv.URL,
+		Token:      "token",
+		LogHandler: defaultTestLoggerHandler,
+	})
+	quotes := NewResource[quote, quote, quote](&c, "quotes")
+
+	ctx := context.Background()
+	if res, err := quotes.Find(ctx, id); err != nil || res.Data.Text != "Lorem" {
+		t.Errorf("Find() = %v, %v", res.Data, err)
+	}
+
+	if res, err := quotes.Random(ctx); err != nil || res.Data.Text != "Lorem" {
+		t.Errorf("Random() = %v, %v", res.Data, err)
+	}
+
+	if res, err := quotes.Create(ctx, quote{Text: "Ipsum"}); err != nil || res.Text != "Ipsum" {
+		t.Errorf("Create() = %v, %v", res, err)
+	}
+
+	if err := quotes.Delete(ctx, id); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+
+	wantPaths := []string{
+		"GET /v1/quotes/" + id.String(),
+		"GET /v1/quotes/random",
+		"POST /v1/quotes",
+		"DELETE /v1/quotes/" + id.String(),
+	}
+	if len(gotPaths) != len(wantPaths) {
+		t.Fatalf("requests = %v, want %v", gotPaths, wantPaths)
+	}
+
+	for i := range wantPaths {
+		if gotPaths[i] != wantPaths[i] {
+			t.Errorf("request[%d] = %v, want %v", i, gotPaths[i], wantPaths[i])
+		}
+	}
+}
+
+func TestResource_Patch(t *testing.T) {
+	type quote struct {
+		Text string `json:"text"`
+	}
+
+	tests := []struct {
+		name      string
+		patchBody string
+		wantPaths []string
+	}{
+		{
+			name:      "should decode the patched item",
+			patchBody: `{"text": "Ipsum"}`,
+			wantPaths: []string{"GET", "PATCH"},
+		},
+		{
+			name:      "should fetch the patched item without cache",
+			patchBody: "",
+			wantPaths: []string{"GET", "PATCH", "GET"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := "Lorem"
+			var gotPaths []string
+			sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPaths = append(gotPaths, r.Method)
+
+				if r.Method == http.MethodPatch {
+					text = "Ipsum"
+					w.Write([]byte(tt.patchBody))
+					return
+				}
+				w.Write([]byte(`{"text": "` + text + `"}`))
+			}))
+			defer sv.Close()
+
+			c := NewClientWithOpts(Options{
+				Endpoint:         sv.URL,
+				Token:            "token",
+				LogHandler:       defaultTestLoggerHandler,
+				UseInMemoryCache: true,
+			})
+			quotes := NewResource[quote, quote, quote](&c, "quotes")
+
+			ctx := context.Background()
+			id := uuid.New()
+			if _, err := quotes.Find(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := quotes.Patch(ctx, id, quote{Text: "Ipsum"})
+			if err != nil || got.Text != "Ipsum" {
+				t.Errorf("Patch() = %v, %v", got, err)
+			}
+
+			if len(gotPaths) != len(tt.wantPaths) {
+				t.Fatalf("requests = %v, want %v", gotPaths, tt.wantPaths)
+			}
+
+			// The cached item was invalidated
+			if res, err := quotes.Find(ctx, id); err != nil || res.Data.Text != "Ipsum" {
+				t.Errorf("Find() = %v, %v", res.Data, err)
+			}
+		})
+	}
+}
+
+func TestResource_Patch_ifMatch(t *testing.T) {
+	type quote struct {
+		Text string `json:"text"`
+	}
+
+	tests := []struct {
+		name         string
+		cache        bool
+		language     string
+		options      []WriteOptions
+		wantRequests []string
+	}{
+		{
+			name:         "should send the etag of the cached and patched items",
+			cache:        true,
+			wantRequests: []string{"GET", `PATCH "v1"`, `PATCH "v2"`},
+		},
+		{
+			name:         "should send the etag of the patched item in another language",
+			cache:        true,
+			language:     "pt-BR",
+			wantRequests: []string{"GET", `PATCH "v1"`, `PATCH "v2"`},
+		},
+		{
+			name:         "should send no precondition without cache",
+			cache:        false,
+			wantRequests: []string{"GET", "PATCH ", "PATCH "},
+		},
+		{
+			name:         "should fetch the etag in strict mode",
+			cache:        false,
+			options:      []WriteOptions{WithStrictIfMatch()},
+			wantRequests: []string{"GET", "GET", `PATCH "v1"`, "GET", `PATCH "v2"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := 1
+			var gotRequests []string
+			sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					gotRequests = append(gotRequests, "PATCH "+r.Header.Get(headerIfMatch))
+					version++
+				} else {
+					gotRequests = append(gotRequests, r.Method)
+				}
+
+				w.Header().Set(apiHeaderEtag, fmt.Sprintf(`"v%d"`, version))
+				w.Write([]byte(`{"text": "Lorem"}`))
+			}))
+			defer sv.Close()
+
+			c := NewClientWithOpts(Options{
+				Endpoint:         sv.URL,
+				Token:            "token",
+				LogHandler:       defaultTestLoggerHandler,
+				Language:         tt.language,
+				UseInMemoryCache: tt.cache,
+			})
+			quotes := NewResource[quote, quote, quote](&c, "quotes")
+
+			ctx := context.Background()
+			id := uuid.New()
+			if _, err := quotes.Find(ctx, id); err != nil {
+				t.Fatal(err)
+			}
+
+			for range 2 {
+				if _, err := quotes.Patch(ctx, id, quote{Text: "Ipsum"}, tt.options...); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if !slices.Equal(gotRequests, tt.wantRequests) {
+				t.Errorf("requests = %q, want %q", gotRequests, tt.wantRequests)
+			}
+		})
+	}
+}
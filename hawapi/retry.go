@@ -0,0 +1,185 @@
+package hawapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryPolicy retries GET requests up to 3 times on gateway and rate limit errors
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.5,
+	RetryableStatus: []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// RetryPolicy defines how failed requests are retried
+type RetryPolicy struct {
+	// The max number of attempts, including the first one
+	//
+	// Set it to 1 to disable retries
+	MaxAttempts int
+
+	// The delay before the first retry, doubled on every attempt
+	BaseDelay time.Duration
+
+	// The max delay between two attempts. When 'Retry-After' asks for a longer delay, the request is not retried
+	MaxDelay time.Duration
+
+	// The fraction (0 to 1) of each delay which will be randomised
+	Jitter float64
+
+	// The response status codes which should be retried
+	RetryableStatus []int
+
+	// Define if POST, PATCH and DELETE requests should also be retried
+	//
+	// By default, only GET requests are retried
+	RetryMutations bool
+}
+
+// RetryError is returned when a request still failed after being retried
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %s", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// merge copies the non-zero fields of other, so a field can be changed without redefining the policy
+func (p *RetryPolicy) merge(other RetryPolicy) {
+	if other.MaxAttempts != 0 {
+		p.MaxAttempts = other.MaxAttempts
+	}
+
+	if other.BaseDelay != 0 {
+		p.BaseDelay = other.BaseDelay
+	}
+
+	if other.MaxDelay != 0 {
+		p.MaxDelay = other.MaxDelay
+	}
+
+	if other.Jitter != 0 {
+		p.Jitter = other.Jitter
+	}
+
+	if other.RetryableStatus != nil {
+		p.RetryableStatus = other.RetryableStatus
+	}
+
+	if other.RetryMutations {
+		p.RetryMutations = true
+	}
+}
+
+// attempts returns the max number of attempts allowed for the request method
+func (p RetryPolicy) attempts(method string) int {
+	if p.MaxAttempts <= 1 {
+		return 1
+	}
+
+	if method != http.MethodGet && !p.RetryMutations {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// retryable is implemented by transport errors which know if they can be retried
+// (e.g. recorder.ErrUnmatched, which would fail again)
+type retryable interface {
+	Retryable() bool
+}
+
+// shouldRetry reports whether the result of an attempt can be retried
+func (p RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if res != nil {
+		return slices.Contains(p.RetryableStatus, res.StatusCode)
+	}
+
+	var r retryable
+	if errors.As(err, &r) && !r.Retryable() {
+		return false
+	}
+
+	// Transport errors are retried, unless the caller gave up
+	return err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+// delay returns how long to wait before the next attempt,
+// or false if the 'Retry-After' header asks to wait longer than MaxDelay
+func (p RetryPolicy) delay(attempt int, res *http.Response) (time.Duration, bool) {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+
+	if p.Jitter > 0 && d > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+
+	if res != nil {
+		if after := parseRetryAfter(res.Header.Get("Retry-After")); after > d {
+			// Retrying earlier than asked would be rejected again
+			if p.MaxDelay > 0 && after > p.MaxDelay {
+				return after, false
+			}
+			d = after
+		}
+	}
+
+	return d, true
+}
+
+// parseRetryAfter parses the 'Retry-After' header, in seconds or as a http date
+func parseRetryAfter(value string) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
+
+// sleep waits for the delay to pass or the context to be done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,217 @@
+package hawapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:     3,
+	BaseDelay:       time.Millisecond,
+	MaxDelay:        10 * time.Millisecond,
+	RetryableStatus: DefaultRetryPolicy.RetryableStatus,
+}
+
+func TestClient_doRequest_retry(t *testing.T) {
+	type args struct {
+		method       string
+		policy       RetryPolicy
+		mockStatuses []int
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name: "should retry get request until it succeeds",
+			args: args{
+				method:       http.MethodGet,
+				policy:       testRetryPolicy,
+				mockStatuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			},
+			wantAttempts: 3,
+			wantErr:      false,
+		},
+		{
+			name: "should give up after max attempts",
+			args: args{
+				method:       http.MethodGet,
+				policy:       testRetryPolicy,
+				mockStatuses: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name: "should not retry if status is not retryable",
+			args: args{
+				method:       http.MethodGet,
+				policy:       testRetryPolicy,
+				mockStatuses: []int{http.StatusInternalServerError, http.StatusOK},
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name: "should not retry mutations by default",
+			args: args{
+				method:       http.MethodPost,
+				policy:       testRetryPolicy,
+				mockStatuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name: "should retry mutations if enabled",
+			args: args{
+				method: http.MethodPost,
+				policy: RetryPolicy{
+					MaxAttempts:     testRetryPolicy.MaxAttempts,
+					BaseDelay:       testRetryPolicy.BaseDelay,
+					RetryableStatus: testRetryPolicy.RetryableStatus,
+					RetryMutations:  true,
+				},
+				mockStatuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			},
+			wantAttempts: 2,
+			wantErr:      false,
+		},
+		{
+			name: "should retry mutations if only enabled",
+			args: args{
+				method:       http.MethodPost,
+				policy:       RetryPolicy{RetryMutations: true},
+				mockStatuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			},
+			wantAttempts: 2,
+			wantErr:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := attempts.Add(1) - 1
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.args.mockStatuses[i])
+				w.Write([]byte(`{}`))
+			}))
+			defer sv.Close()
+
+			c := NewClientWithOpts(Options{
+				LogHandler: defaultTestLoggerHandler,
+				Retry:      tt.args.policy,
+			})
+
+			req, err := http.NewRequest(tt.args.method, sv.URL, bytes.NewBufferString(`{}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = c.doRequest(req, http.StatusOK, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("doRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := int(attempts.Load()); got != tt.wantAttempts {
+				t.Errorf("doRequest() attempts = %v, want %v", got, tt.wantAttempts)
+			}
+
+			var retryErr *RetryError
+			if errors.As(err, &retryErr) && retryErr.Attempts != tt.wantAttempts {
+				t.Errorf("RetryError.Attempts = %v, want %v", retryErr.Attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_doRequest_retryCanceled(t *testing.T) {
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer sv.Close()
+
+	c := NewClientWithOpts(Options{
+		LogHandler: defaultTestLoggerHandler,
+		Retry: RetryPolicy{
+			MaxAttempts:     5,
+			BaseDelay:       time.Hour,
+			RetryableStatus: DefaultRetryPolicy.RetryableStatus,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.doRequest(req, http.StatusOK, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("doRequest() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		attempt    int
+		retryAfter string
+		want       time.Duration
+		wantRetry  bool
+	}{
+		{
+			name:      "should double the delay on every attempt",
+			policy:    RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute},
+			attempt:   3,
+			want:      4 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name:      "should not exceed max delay",
+			policy:    RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second},
+			attempt:   5,
+			want:      3 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name:       "should honour retry after header",
+			policy:     RetryPolicy{BaseDelay: time.Second, MaxDelay: time.Minute},
+			attempt:    1,
+			retryAfter: "7",
+			want:       7 * time.Second,
+			wantRetry:  true,
+		},
+		{
+			name:       "should not retry if retry after header exceeds max delay",
+			policy:     RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second},
+			attempt:    1,
+			retryAfter: "120",
+			want:       120 * time.Second,
+			wantRetry:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			res.Header.Set("Retry-After", tt.retryAfter)
+
+			got, retry := tt.policy.delay(tt.attempt, res)
+			if got != tt.want || retry != tt.wantRetry {
+				t.Errorf("delay() = %v, %v, want %v, %v", got, retry, tt.want, tt.wantRetry)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package hawapi
 
 import (
+	"context"
+	"iter"
+
 	"github.com/google/uuid"
 )
 
@@ -46,99 +49,87 @@ type CreateSeason struct {
 	Sources       []string `json:"sources,omitempty"`
 }
 
-type PatchSeason = CreateSeason
-
-type SeasonResponse struct {
-	BaseResponse
-	Data Season `json:"data"`
+// PatchSeason is a JSON Merge Patch, only its set fields are updated
+type PatchSeason struct {
+	Title         Optional[string]   `json:"title,omitzero"`
+	Description   Optional[string]   `json:"description,omitzero"`
+	Language      Optional[string]   `json:"language,omitzero"`
+	Genres        Optional[[]string] `json:"genres,omitzero"`
+	Episodes      Optional[[]string] `json:"episodes,omitzero"`
+	Trailers      Optional[[]string] `json:"trailers,omitzero"`
+	Budget        Optional[int]      `json:"budget,omitzero"`
+	DurationTotal Optional[int64]    `json:"duration_total,omitzero"`
+	SeasonNum     Optional[byte]     `json:"season_num,omitzero"`
+	ReleaseDate   Optional[string]   `json:"release_date,omitzero"`
+	NextSeason    Optional[string]   `json:"next_season,omitzero"`
+	PrevSeason    Optional[string]   `json:"prev_season,omitzero"`
+	Thumbnail     Optional[string]   `json:"thumbnail,omitzero"`
+	Images        Optional[[]string] `json:"images,omitzero"`
+	Sources       Optional[[]string] `json:"sources,omitzero"`
 }
 
-type SeasonListResponse struct {
-	BaseResponse
-	Data []Season `json:"data"`
+type SeasonResponse = ItemResponse[Season]
+
+type SeasonListResponse = ListResponse[Season]
+
+// Seasons returns the typed client for seasons
+func (c *Client) Seasons() Resource[Season, CreateSeason, PatchSeason] {
+	return NewResource[Season, CreateSeason, PatchSeason](c, seasonOrigin)
 }
 
 // ListSeasons will get all seasons
 func (c *Client) ListSeasons(options ...QueryOptions) (SeasonListResponse, error) {
-	var seasons []Season
-	var res SeasonListResponse
-
-	doRes, err := c.doGetRequest(seasonOrigin, options, &seasons)
-	if err != nil {
-		return res, err
-	}
+	return c.Seasons().List(context.Background(), options...)
+}
 
-	res = SeasonListResponse{
-		BaseResponse: doRes,
-		Data:         seasons,
-	}
+// ListSeasonsContext will get all seasons using the given context
+func (c *Client) ListSeasonsContext(ctx context.Context, options ...QueryOptions) (SeasonListResponse, error) {
+	return c.Seasons().List(ctx, options...)
+}
 
-	return res, nil
+// AllSeasons iterates over all seasons of every page, starting at the page defined by the options
+func (c *Client) AllSeasons(ctx context.Context, options ...QueryOptions) iter.Seq2[Season, error] {
+	return c.Seasons().All(ctx, options...)
 }
 
 // FindSeason will get a single item by uuid
 func (c *Client) FindSeason(id uuid.UUID) (SeasonResponse, error) {
-	var season Season
-	var res SeasonResponse
-
-	doRes, err := c.doGetRequest(seasonOrigin+"/"+id.String(), nil, &season)
-	if err != nil {
-		return res, err
-	}
-
-	res = SeasonResponse{
-		BaseResponse: doRes,
-		Data:         season,
-	}
+	return c.Seasons().Find(context.Background(), id)
+}
 
-	return res, nil
+// FindSeasonContext will get a single item by uuid using the given context
+func (c *Client) FindSeasonContext(ctx context.Context, id uuid.UUID) (SeasonResponse, error) {
+	return c.Seasons().Find(ctx, id)
 }
 
 func (c *Client) RandomSeason() (SeasonResponse, error) {
-	var season Season
-	var res SeasonResponse
-
-	doRes, err := c.doGetRequest(seasonOrigin+"/random", nil, &season)
-	if err != nil {
-		return res, err
-	}
-
-	res = SeasonResponse{
-		BaseResponse: doRes,
-		Data:         season,
-	}
+	return c.Seasons().Random(context.Background())
+}
 
-	return res, nil
+func (c *Client) RandomSeasonContext(ctx context.Context) (SeasonResponse, error) {
+	return c.Seasons().Random(ctx)
 }
 
 func (c *Client) CreateSeason(s CreateSeason) (Season, error) {
-	var season Season
-
-	err := c.doPostRequest(seasonOrigin, s, &season)
-	if err != nil {
-		return season, err
-	}
-
-	return season, nil
+	return c.Seasons().Create(context.Background(), s)
 }
 
-func (c *Client) PatchSeason(id uuid.UUID, p PatchSeason) (Season, error) {
-	var season Season
+func (c *Client) CreateSeasonContext(ctx context.Context, s CreateSeason) (Season, error) {
+	return c.Seasons().Create(ctx, s)
+}
 
-	err := c.doPatchRequest(seasonOrigin+"/"+id.String(), &p)
-	if err != nil {
-		return season, err
-	}
+func (c *Client) PatchSeason(id uuid.UUID, p PatchSeason, options ...WriteOptions) (Season, error) {
+	return c.Seasons().Patch(context.Background(), id, p, options...)
+}
 
-	res, err := c.FindSeason(id)
-	if err != nil {
-		return season, err
-	}
+func (c *Client) PatchSeasonContext(ctx context.Context, id uuid.UUID, p PatchSeason, options ...WriteOptions) (Season, error) {
+	return c.Seasons().Patch(ctx, id, p, options...)
+}
 
-	season = res.Data
-	return season, nil
+func (c *Client) DeleteSeason(id uuid.UUID, options ...WriteOptions) error {
+	return c.Seasons().Delete(context.Background(), id, options...)
 }
 
-func (c *Client) DeleteSeason(id uuid.UUID) error {
-	return c.doDeleteRequest(seasonOrigin + "/" + id.String())
+func (c *Client) DeleteSeasonContext(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return c.Seasons().Delete(ctx, id, options...)
 }
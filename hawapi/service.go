@@ -2,19 +2,31 @@ package hawapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/HawAPI/go-sdk/pkg/cache"
 )
 
 type cachedBaseResponse struct {
 	BaseResponse
-	data []byte
+
+	// The resource origin (e.g. 'actors')
+	origin string
+
+	// The original response body
+	data     []byte
+	storedAt time.Time
 }
 
 const (
@@ -41,49 +53,185 @@ const (
 
 	// ApiHeaderEtag is the API content etag
 	apiHeaderEtag = "ETag"
+
+	// headerIfNoneMatch is the conditional request header used to revalidate cached responses
+	headerIfNoneMatch = "If-None-Match"
+
+	// headerIfMatch is the conditional request header used to avoid lost updates
+	headerIfMatch = "If-Match"
+
+	// contentTypeMergePatch is the content type of PATCH request bodies
+	contentTypeMergePatch = "application/merge-patch+json"
 )
 
-func (c *Client) doRequest(req *http.Request, wantStatus int, out any) (http.Header, error) {
-	if r := reflect.ValueOf(out); out != nil && r.Kind() != reflect.Ptr {
-		return nil, fmt.Errorf("out must be a pointer")
+// doRequest sends the request, retrying it if needed, and decodes the response body into out.
+// If out is a *json.RawMessage, the body is copied without being decoded.
+//
+// A '304 Not Modified' response to a conditional request is also successful, but its body is not decoded.
+func (c *Client) doRequest(req *http.Request, wantStatus int, out any) (*http.Response, error) {
+	if err := checkOut(out); err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	// Token is optional
-	if len(c.options.Token) != 0 {
-		req.Header.Set("Authorization", "Bearer "+c.options.Token)
+	if len(req.Header.Get("Content-Type")) == 0 {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	res, err := c.client.Do(req)
+	// Token is optional
+	token, err := c.token(req.Context())
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	if len(token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
-	if res.StatusCode != wantStatus {
-		var resErr ErrorResponse
-		if err := json.Unmarshal(body, &resErr); err != nil {
-			return nil, errors.New("failed to parse error message: " + err.Error())
+	policy := c.options.Retry
+	maxAttempts := policy.attempts(req.Method)
+
+	// A rejected token is refreshed once, without counting as an attempt
+	refreshed := false
+
+	for attempt := 1; ; attempt++ {
+		if err := c.checkQuota(req.Context()); err != nil {
+			return nil, err
 		}
-		return nil, resErr
-	}
 
-	if out != nil {
-		if err := json.Unmarshal(body, out); err != nil {
+		c.logger.Debug(fmt.Sprintf("%s '%s' (attempt %d of %d)", req.Method, req.URL, attempt, maxAttempts))
+
+		res, body, err := c.doAttempt(req)
+		if err == nil && res.StatusCode == http.StatusNotModified && req.Header.Get(headerIfNoneMatch) != "" {
+			return res, nil
+		}
+
+		if err == nil && res.StatusCode == wantStatus {
+			// Raw bodies are kept as is, even if empty
+			if raw, ok := out.(*json.RawMessage); ok {
+				*raw = body
+			} else if out != nil {
+				if err := json.Unmarshal(body, out); err != nil {
+					return nil, err
+				}
+			}
+
+			return res, nil
+		}
+
+		if err == nil {
+			// Other JSON bodies (e.g. '{"message": "Internal server error"}' from a gateway) aren't API errors
+			var resErr ErrorResponse
+			if jsonErr := json.Unmarshal(body, &resErr); jsonErr != nil || !resErr.fromAPI() {
+				err = newHTTPError(req, res, body)
+			} else {
+				// Errors are classified by code, so fill it if missing from the body
+				if resErr.Code == 0 {
+					resErr.Code = res.StatusCode
+				}
+				err = resErr
+			}
+		}
+
+		if err != nil && res != nil && res.StatusCode == http.StatusUnauthorized && !refreshed && c.tokens.hasSource() {
+			refreshed = true
+			c.tokens.invalidate(token)
+
+			newToken, tokenErr := c.tokens.Token(req.Context())
+			if tokenErr != nil {
+				return nil, tokenErr
+			}
+
+			// Retrying with the same token would be rejected again
+			if newToken != token {
+				c.logger.Warn(fmt.Sprintf("%s '%s' was unauthorized, retrying with a refreshed token", req.Method, req.URL))
+
+				token = newToken
+				req.Header.Set("Authorization", "Bearer "+token)
+				if err := rewindBody(req); err != nil {
+					return nil, err
+				}
+
+				attempt--
+				continue
+			}
+		}
+
+		retry := attempt < maxAttempts && policy.shouldRetry(res, err)
+
+		var delay time.Duration
+		if retry {
+			delay, retry = policy.delay(attempt, res)
+			if !retry {
+				c.logger.Warn(fmt.Sprintf("%s '%s' failed, not retrying as 'Retry-After' (%s) exceeds the max delay", req.Method, req.URL, delay))
+			}
+		}
+
+		if !retry {
+			if attempt > 1 {
+				return nil, &RetryError{Attempts: attempt, Err: err}
+			}
+			return nil, err
+		}
+
+		c.logger.Warn(fmt.Sprintf("%s '%s' failed (attempt %d of %d), retrying in %s: %s", req.Method, req.URL, attempt, maxAttempts, delay, err))
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, &RetryError{Attempts: attempt, Err: err}
+		}
+
+		if err := rewindBody(req); err != nil {
 			return nil, err
 		}
 	}
+}
 
-	return res.Header, nil
+// checkOut returns ErrInvalidOut if out can't be used to decode a response
+func checkOut(out any) error {
+	if r := reflect.ValueOf(out); out != nil && r.Kind() != reflect.Ptr {
+		return ErrInvalidOut
+	}
+	return nil
 }
 
-func (c *Client) doGetRequest(origin string, query []QueryOptions, out any) (BaseResponse, error) {
+// rewindBody resets the request body, consumed by the previous attempt
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	return nil
+}
+
+// doAttempt sends the request once and reads the whole response body
+func (c *Client) doAttempt(req *http.Request) (*http.Response, []byte, error) {
+	doer := c.doer
+	if doer == nil {
+		doer = c.client
+	}
+
+	res, err := doer.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	c.quota.update(res)
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, body, nil
+}
+
+func (c *Client) doGetRequest(ctx context.Context, origin string, query []QueryOptions, out any) (BaseResponse, error) {
 	var res BaseResponse
 
 	// This will fix 'buildUrl' ignoring url options if 'query' is nil
@@ -93,58 +241,151 @@ func (c *Client) doGetRequest(origin string, query []QueryOptions, out any) (Bas
 
 	url := c.buildUrl(origin, query)
 
-	cached, ok := c.cache.Get(url)
+	// Don't serve cached values to an already cancelled request
+	if err := ctx.Err(); err != nil {
+		return res, err
+	}
+
+	policy := c.cachePolicy(origin)
+	resource, _, _ := strings.Cut(origin, "/")
+
+	// Stale responses with an etag are revalidated, instead of fetched again
+	var stale *cachedBaseResponse
+
+	var cached any
+	var ok bool
+	if policy.Enabled {
+		cached, ok = c.cache.Get(url)
+	}
+
 	if ok {
 		cbr := cached.(cachedBaseResponse)
 
-		// If the cache doesn't work, we fetch the data again
-		if err := json.Unmarshal(cbr.data, out); err == nil {
-			c.logger.Debug(fmt.Sprintf("found cached response for key %s", url))
-			return cbr.BaseResponse, nil
+		if time.Since(cbr.storedAt) < c.options.CacheMaxAge {
+			// If the cache doesn't work, we fetch the data again
+			if err := json.Unmarshal(cbr.data, out); err == nil {
+				c.logger.Debug(fmt.Sprintf("found cached response for key %s", url))
+				c.stats.record(resource, cacheHit)
+				return cbr.BaseResponse, nil
+			}
+
+			c.logger.Warn("failed to parse response from in-memory cache, fetching...")
+		} else {
+			stale = &cbr
 		}
-
-		c.logger.Warn("failed to parse response from in-memory cache, fetching...")
 	}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return res, err
 	}
 
-	httpHeader, err := c.doRequest(req, http.StatusOK, out)
+	if stale != nil && len(stale.Etag) != 0 {
+		req.Header.Set(headerIfNoneMatch, stale.Etag)
+	}
+
+	if err := checkOut(out); err != nil {
+		return res, err
+	}
+
+	// The body is kept, so it can be cached without being encoded again
+	var body json.RawMessage
+	httpRes, err := c.doRequest(req, http.StatusOK, &body)
 	if err != nil {
+		if stale != nil && c.options.CacheServeStaleOnError && canServeStale(err) {
+			if jsonErr := json.Unmarshal(stale.data, out); jsonErr == nil {
+				c.logger.Warn(fmt.Sprintf("serving stale cached response for key %s: %s", url, err))
+				c.stats.record(resource, cacheStaleServe)
+				return stale.BaseResponse, nil
+			}
+		}
+
+		if policy.Enabled {
+			c.stats.record(resource, cacheMiss)
+		}
 		return res, err
 	}
 
-	headers := extractHeaders(httpHeader)
+	if httpRes.StatusCode == http.StatusNotModified {
+		c.stats.record(resource, cacheRevalidation)
+		return c.revalidated(url, policy, stale, httpRes.Header, out)
+	}
+
+	if policy.Enabled {
+		c.stats.record(resource, cacheMiss)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return res, err
+	}
+
+	headers := extractHeaders(httpRes.Header)
 	res = BaseResponse{
 		HeaderResponse: headers,
 		Status:         http.StatusOK,
 	}
 
-	if c.options.UseInMemoryCache {
+	if c.options.UseInMemoryCache && policy.Enabled {
 		res.Cached = true
 
-		bOut, err := json.Marshal(out)
-		if err != nil {
-			return res, err
-		}
-
 		cbr := cachedBaseResponse{
 			BaseResponse: res,
-			data:         bOut,
+			origin:       resource,
+			data:         body,
+			storedAt:     time.Now(),
 		}
 
-		c.logger.Debug(fmt.Sprintf("cached response using '%s' as key", url))
-		c.cache.Set(url, cbr)
+		c.logger.Debug(fmt.Sprintf("cached %s response using '%s' as key", endpointOf(origin), url))
+		c.setCached(url, policy, cbr)
 	}
 
 	return res, nil
 }
 
-func (c *Client) doPostRequest(origin string, in any, out any) error {
-	if len(c.options.Token) == 0 {
-		return fmt.Errorf("token is required for post request")
+// canServeStale reports whether a stale response can be served instead of the error,
+// which must be caused by the API or the network, not by the request itself
+func canServeStale(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, ErrServer) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrQuotaExhausted) {
+		return true
+	}
+
+	// Other API errors (e.g. '404 Not Found') mean the cached response is wrong
+	var resErr ErrorResponse
+	var httpErr HTTPError
+	return !errors.As(err, &resErr) && !errors.As(err, &httpErr)
+}
+
+// revalidated refreshes a stale cached response after the API answered '304 Not Modified'
+func (c *Client) revalidated(url string, policy CachePolicy, stale *cachedBaseResponse, header http.Header, out any) (BaseResponse, error) {
+	if err := json.Unmarshal(stale.data, out); err != nil {
+		return BaseResponse{}, err
+	}
+
+	headers := extractHeaders(header)
+	if len(headers.Etag) != 0 {
+		stale.Etag = headers.Etag
+	}
+
+	if headers.Quota.Remaining >= 0 {
+		stale.Quota = headers.Quota
+	}
+
+	stale.Cached = true
+	stale.storedAt = time.Now()
+
+	c.logger.Debug(fmt.Sprintf("revalidated cached response for key %s", url))
+	c.setCached(url, policy, *stale)
+
+	return stale.BaseResponse, nil
+}
+
+func (c *Client) doPostRequest(ctx context.Context, origin string, in any, out any) error {
+	if !c.hasToken() {
+		return fmt.Errorf("%w for post request", ErrTokenRequired)
 	}
 
 	url := c.buildUrl(origin, nil)
@@ -153,7 +394,7 @@ func (c *Client) doPostRequest(origin string, in any, out any) error {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -163,68 +404,148 @@ func (c *Client) doPostRequest(origin string, in any, out any) error {
 		return err
 	}
 
+	c.invalidate(origin)
 	return nil
 }
 
-func (c *Client) doPatchRequest(origin string, patch any) error {
-	if len(c.options.Token) == 0 {
-		return fmt.Errorf("token is required for put request")
+// doPatchRequest sends the patch as a JSON Merge Patch (RFC 7396), and decodes the patched item into out.
+//
+// It returns false if the API didn't answer with the patched item, leaving out unchanged
+func (c *Client) doPatchRequest(ctx context.Context, origin string, patch any, options []WriteOptions, out any) (bool, error) {
+	if !c.hasToken() {
+		return false, fmt.Errorf("%w for patch request", ErrTokenRequired)
 	}
 
-	var item any
-	_, err := c.doGetRequest(origin, nil, &item)
+	body, err := json.Marshal(patch)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	res, err := json.Marshal(patch)
+	url := c.buildUrl(origin, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(body))
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	err = json.Unmarshal(res, &item)
-	if err != nil {
-		return err
+	req.Header.Set("Content-Type", contentTypeMergePatch)
+	if err := c.setIfMatch(req, origin, options); err != nil {
+		return false, err
 	}
 
-	itemBytes, err := json.Marshal(item)
+	var raw json.RawMessage
+	httpRes, err := c.doRequest(req, http.StatusOK, &raw)
 	if err != nil {
-		return err
+		c.invalidateOnConflict(origin, err)
+		return false, err
 	}
 
-	url := c.buildUrl(origin, nil)
-	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(itemBytes))
-	if err != nil {
-		return err
+	c.invalidate(origin)
+
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return false, nil
 	}
 
-	_, err = c.doRequest(req, http.StatusOK, nil)
-	if err != nil {
-		return err
+	if err := json.Unmarshal(raw, out); err != nil {
+		return false, err
 	}
 
-	return nil
+	// The patched item is cached, so its new ETag is sent by the following PATCH or DELETE
+	policy := c.cachePolicy(origin)
+	if c.options.UseInMemoryCache && policy.Enabled {
+		resource, _, _ := strings.Cut(origin, "/")
+		c.setCached(c.cacheKey(origin), policy, cachedBaseResponse{
+			BaseResponse: BaseResponse{
+				HeaderResponse: extractHeaders(httpRes.Header),
+				Cached:         true,
+				Status:         http.StatusOK,
+			},
+			origin:   resource,
+			data:     raw,
+			storedAt: time.Now(),
+		})
+	}
+
+	return true, nil
 }
 
-func (c *Client) doDeleteRequest(origin string) error {
-	if len(c.options.Token) == 0 {
-		return fmt.Errorf("token is required for delete request")
+func (c *Client) doDeleteRequest(ctx context.Context, origin string, options []WriteOptions) error {
+	if !c.hasToken() {
+		return fmt.Errorf("%w for delete request", ErrTokenRequired)
 	}
 
 	url := c.buildUrl(origin, nil)
-	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return err
 	}
 
+	if err := c.setIfMatch(req, origin, options); err != nil {
+		return err
+	}
+
 	_, err = c.doRequest(req, http.StatusNoContent, nil)
 	if err != nil {
+		c.invalidateOnConflict(origin, err)
 		return err
 	}
 
+	c.invalidate(origin)
 	return nil
 }
 
+// invalidateOnConflict removes the cached item when the API rejected its ETag,
+// so the item can be read again and the request retried with the new ETag
+func (c *Client) invalidateOnConflict(origin string, err error) {
+	if errors.Is(err, ErrPreconditionFailed) {
+		c.invalidate(origin)
+	}
+}
+
+// invalidate removes the cached responses affected by a mutation of origin (e.g. 'actors' or 'actors/<uuid>'):
+// the item, and every list and random response of the resource
+func (c *Client) invalidate(origin string) {
+	if c.cache == nil {
+		return
+	}
+
+	resource, item, _ := strings.Cut(origin, "/")
+
+	// Keys built without query options (see doGetRequest)
+	keys := []string{c.cacheKey(resource), c.cacheKey(resource + "/random")}
+	if len(item) != 0 {
+		keys = append(keys, c.cacheKey(origin))
+	}
+
+	for _, key := range keys {
+		c.cache.Del(key)
+	}
+
+	// Keys built with other query options (e.g. pages) can only be removed by prefix
+	pc, ok := c.cache.(cache.PrefixCache)
+	if !ok {
+		c.logger.Debug(fmt.Sprintf("invalidated cached responses of '%s', without prefix deletion", origin))
+		return
+	}
+
+	base := c.buildUrl(resource, nil)
+	prefixes := []string{base + "?", base + "/random"}
+	if len(item) != 0 {
+		prefixes = append(prefixes, base+"/"+item)
+	}
+
+	count := 0
+	for _, prefix := range prefixes {
+		count += pc.DelPrefix(prefix)
+	}
+
+	c.logger.Debug(fmt.Sprintf("invalidated cached responses of '%s' (%d by prefix)", origin, count))
+}
+
+// cacheKey returns the url, and cache key, of an origin fetched without query options (see doGetRequest)
+func (c *Client) cacheKey(origin string) string {
+	return c.buildUrl(origin, []QueryOptions{})
+}
+
 func (c *Client) buildUrl(origin string, query []QueryOptions) string {
 	url := fmt.Sprintf("%s/%s/%s", c.options.Endpoint, c.options.Version, origin)
 
@@ -251,8 +572,14 @@ func (c *Client) buildUrl(origin string, query []QueryOptions) string {
 		opt(&opts)
 	}
 
-	for key, value := range opts.Filters {
-		if value != "" {
+	// Filters are sorted (language first), so the same query always builds the same url and cache key
+	keys := slices.Sorted(maps.Keys(opts.Filters))
+	if i := slices.Index(keys, "language"); i > 0 {
+		keys = append([]string{"language"}, slices.Delete(keys, i, i+1)...)
+	}
+
+	for _, key := range keys {
+		if value := opts.Filters[key]; value != "" {
 			params = pushOrOverwrite(params, key, value)
 		}
 	}
@@ -1,12 +1,15 @@
 package hawapi
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/HawAPI/go-sdk/pkg/cache"
 )
@@ -100,20 +103,19 @@ func TestClient_buildUrl(t *testing.T) {
 			},
 			want: "https://hawapi.theproject.id/api/v1/actors",
 		},
-		// TODO: Fix overwrite test, sometimes params will in different order
-		//{
-		//	name:   "should build overwrite filter if is already set",
-		//	fields: fields{},
-		//	args: args{
-		//		origin: "actors",
-		//		query: []QueryOptions{
-		//			WithFilter("gender", "1"),
-		//			WithFilter("first_name", "Finn"),
-		//			WithFilter("gender", "0"),
-		//		},
-		//	},
-		//	want: "https://hawapi.theproject.id/api/v1/actors?gender=0&first_name=Finn",
-		//},
+		{
+			name:   "should build overwrite filter if is already set",
+			fields: fields{},
+			args: args{
+				origin: "actors",
+				query: []QueryOptions{
+					WithFilter("gender", "1"),
+					WithFilter("first_name", "Finn"),
+					WithFilter("gender", "0"),
+				},
+			},
+			want: "https://hawapi.theproject.id/api/v1/actors?first_name=Finn&gender=0",
+		},
 		{
 			name:   "should build a complete url",
 			fields: fields{},
@@ -400,7 +402,7 @@ func TestClient_doGetRequest(t *testing.T) {
 				logger:  defaultTestLogger,
 			}
 
-			got, err := c.doGetRequest(tt.args.origin, tt.args.query, tt.args.out)
+			got, err := c.doGetRequest(context.Background(), tt.args.origin, tt.args.query, tt.args.out)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("doGetRequest() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -412,3 +414,109 @@ func TestClient_doGetRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_doGetRequest_canceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"first_name": "Lorem", "last_name": "Ipsum"}`))
+	}))
+	defer server.Close()
+
+	options := DefaultOptions
+	options.Endpoint = server.URL
+	c := &Client{
+		options: options,
+		client:  server.Client(),
+		cache:   cache.NewMemoryCache(),
+		logger:  defaultTestLogger,
+	}
+
+	// Warm up the cache, so the canceled request would be able to hit it
+	if _, err := c.doGetRequest(context.Background(), "actors", nil, &Actor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.doGetRequest(ctx, "actors", nil, &Actor{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("doGetRequest() error = %v, want %v", err, context.Canceled)
+	}
+
+	_, err = c.doGetRequest(ctx, "actors/random", nil, &Actor{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("doGetRequest() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClient_doGetRequest_revalidate(t *testing.T) {
+	var requests, conditionalRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.Header().Set(apiHeaderEtag, `"v1"`)
+
+		if req.Header.Get(headerIfNoneMatch) == `"v1"` {
+			conditionalRequests++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Write([]byte(`{"first_name": "Lorem", "last_name": "Ipsum"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name                    string
+		maxAge                  time.Duration
+		wantRequests            int
+		wantConditionalRequests int
+	}{
+		{
+			name:                    "should use fresh cached response",
+			maxAge:                  time.Hour,
+			wantRequests:            1,
+			wantConditionalRequests: 0,
+		},
+		{
+			name:                    "should revalidate stale cached response",
+			maxAge:                  time.Nanosecond,
+			wantRequests:            3,
+			wantConditionalRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests, conditionalRequests = 0, 0
+
+			options := DefaultOptions
+			options.Endpoint = server.URL
+			options.CacheMaxAge = tt.maxAge
+			c := &Client{
+				options: options,
+				client:  server.Client(),
+				cache:   cache.NewMemoryCache(),
+				logger:  defaultTestLogger,
+			}
+
+			for i := 0; i < 3; i++ {
+				var actor Actor
+				got, err := c.doGetRequest(context.Background(), "actors", nil, &actor)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if actor.FirstName != "Lorem" || !got.Cached || got.Etag != `"v1"` {
+					t.Errorf("doGetRequest() got = %v, actor = %v", got, actor)
+				}
+			}
+
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			if conditionalRequests != tt.wantConditionalRequests {
+				t.Errorf("conditional requests = %v, want %v", conditionalRequests, tt.wantConditionalRequests)
+			}
+		})
+	}
+}
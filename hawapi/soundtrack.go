@@ -1,6 +1,9 @@
 package hawapi
 
 import (
+	"context"
+	"iter"
+
 	"github.com/google/uuid"
 )
 
@@ -34,99 +37,81 @@ type CreateSoundtrack struct {
 	Sources     []string `json:"sources,omitempty"`
 }
 
-type PatchSoundtrack = CreateSoundtrack
-
-type SoundtrackResponse struct {
-	BaseResponse
-	Data Soundtrack `json:"data"`
+// PatchSoundtrack is a JSON Merge Patch, only its set fields are updated
+type PatchSoundtrack struct {
+	Name        Optional[string]   `json:"name,omitzero"`
+	Duration    Optional[int64]    `json:"duration,omitzero"`
+	Artist      Optional[string]   `json:"artist,omitzero"`
+	Album       Optional[string]   `json:"album,omitzero"`
+	ReleaseDate Optional[string]   `json:"release_date,omitzero"`
+	Urls        Optional[[]string] `json:"urls,omitzero"`
+	Thumbnail   Optional[string]   `json:"thumbnail,omitzero"`
+	Images      Optional[[]string] `json:"images,omitzero"`
+	Sources     Optional[[]string] `json:"sources,omitzero"`
 }
 
-type SoundtrackListResponse struct {
-	BaseResponse
-	Data []Soundtrack `json:"data"`
+type SoundtrackResponse = ItemResponse[Soundtrack]
+
+type SoundtrackListResponse = ListResponse[Soundtrack]
+
+// Soundtracks returns the typed client for soundtracks
+func (c *Client) Soundtracks() Resource[Soundtrack, CreateSoundtrack, PatchSoundtrack] {
+	return NewResource[Soundtrack, CreateSoundtrack, PatchSoundtrack](c, soundtrackOrigin)
 }
 
 // ListSoundtracks will get all soundtracks
 func (c *Client) ListSoundtracks(options ...QueryOptions) (SoundtrackListResponse, error) {
-	var soundtracks []Soundtrack
-	var res SoundtrackListResponse
-
-	doRes, err := c.doGetRequest(soundtrackOrigin, options, &soundtracks)
-	if err != nil {
-		return res, err
-	}
+	return c.Soundtracks().List(context.Background(), options...)
+}
 
-	res = SoundtrackListResponse{
-		BaseResponse: doRes,
-		Data:         soundtracks,
-	}
+// ListSoundtracksContext will get all soundtracks using the given context
+func (c *Client) ListSoundtracksContext(ctx context.Context, options ...QueryOptions) (SoundtrackListResponse, error) {
+	return c.Soundtracks().List(ctx, options...)
+}
 
-	return res, nil
+// AllSoundtracks iterates over all soundtracks of every page, starting at the page defined by the options
+func (c *Client) AllSoundtracks(ctx context.Context, options ...QueryOptions) iter.Seq2[Soundtrack, error] {
+	return c.Soundtracks().All(ctx, options...)
 }
 
 // FindSoundtrack will get a single item by uuid
 func (c *Client) FindSoundtrack(id uuid.UUID) (SoundtrackResponse, error) {
-	var soundtrack Soundtrack
-	var res SoundtrackResponse
-
-	doRes, err := c.doGetRequest(soundtrackOrigin+"/"+id.String(), nil, &soundtrack)
-	if err != nil {
-		return res, err
-	}
-
-	res = SoundtrackResponse{
-		BaseResponse: doRes,
-		Data:         soundtrack,
-	}
+	return c.Soundtracks().Find(context.Background(), id)
+}
 
-	return res, nil
+// FindSoundtrackContext will get a single item by uuid using the given context
+func (c *Client) FindSoundtrackContext(ctx context.Context, id uuid.UUID) (SoundtrackResponse, error) {
+	return c.Soundtracks().Find(ctx, id)
 }
 
 func (c *Client) RandomSoundtrack() (SoundtrackResponse, error) {
-	var soundtrack Soundtrack
-	var res SoundtrackResponse
-
-	doRes, err := c.doGetRequest(soundtrackOrigin+"/random", nil, &soundtrack)
-	if err != nil {
-		return res, err
-	}
-
-	res = SoundtrackResponse{
-		BaseResponse: doRes,
-		Data:         soundtrack,
-	}
+	return c.Soundtracks().Random(context.Background())
+}
 
-	return res, nil
+func (c *Client) RandomSoundtrackContext(ctx context.Context) (SoundtrackResponse, error) {
+	return c.Soundtracks().Random(ctx)
 }
 
 func (c *Client) CreateSoundtrack(s CreateSoundtrack) (Soundtrack, error) {
-	var soundtrack Soundtrack
-
-	err := c.doPostRequest(soundtrackOrigin, s, &soundtrack)
-	if err != nil {
-		return soundtrack, err
-	}
-
-	return soundtrack, nil
+	return c.Soundtracks().Create(context.Background(), s)
 }
 
-func (c *Client) PatchSoundtrack(id uuid.UUID, p PatchSoundtrack) (Soundtrack, error) {
-	var soundtrack Soundtrack
+func (c *Client) CreateSoundtrackContext(ctx context.Context, s CreateSoundtrack) (Soundtrack, error) {
+	return c.Soundtracks().Create(ctx, s)
+}
 
-	err := c.doPatchRequest(soundtrackOrigin+"/"+id.String(), &p)
-	if err != nil {
-		return soundtrack, err
-	}
+func (c *Client) PatchSoundtrack(id uuid.UUID, p PatchSoundtrack, options ...WriteOptions) (Soundtrack, error) {
+	return c.Soundtracks().Patch(context.Background(), id, p, options...)
+}
 
-	res, err := c.FindSoundtrack(id)
-	if err != nil {
-		return soundtrack, err
-	}
+func (c *Client) PatchSoundtrackContext(ctx context.Context, id uuid.UUID, p PatchSoundtrack, options ...WriteOptions) (Soundtrack, error) {
+	return c.Soundtracks().Patch(ctx, id, p, options...)
+}
 
-	soundtrack = res.Data
-	return soundtrack, nil
+func (c *Client) DeleteSoundtrack(id uuid.UUID, options ...WriteOptions) error {
+	return c.Soundtracks().Delete(context.Background(), id, options...)
 }
 
-func (c *Client) DeleteSoundtrack(id uuid.UUID) error {
-	return c.doDeleteRequest(soundtrackOrigin + "/" + id.String())
+func (c *Client) DeleteSoundtrackContext(ctx context.Context, id uuid.UUID, options ...WriteOptions) error {
+	return c.Soundtracks().Delete(ctx, id, options...)
 }
@@ -0,0 +1,181 @@
+package hawapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenExpiryLeeway is how long before its 'exp' claim a token is refreshed
+const DefaultTokenExpiryLeeway = 30 * time.Second
+
+// TokenSource provides the HawAPI token (JWT) used by requests
+//
+// The client keeps the returned token until it expires (see DefaultTokenExpiryLeeway),
+// or until the API rejects it with '401 Unauthorized'
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is a TokenSource calling a function, e.g. to fetch a token from a secret manager
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx)
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource always providing the same token
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// FileTokenSource returns a TokenSource reading the token from a file, each time it is refreshed
+func FileTokenSource(path string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token: %w", err)
+		}
+
+		token := strings.TrimSpace(string(b))
+		if len(token) == 0 {
+			return "", fmt.Errorf("failed to read token: '%s' is empty", path)
+		}
+
+		return token, nil
+	})
+}
+
+// tokenCache keeps the token of a TokenSource until it expires or is rejected
+//
+// Without source, requests are sent without token
+type tokenCache struct {
+	source TokenSource
+	now    func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newTokenCache(source TokenSource) *tokenCache {
+	return &tokenCache{source: source, now: time.Now}
+}
+
+// Token returns the cached token, refreshing it if missing or expired
+func (t *tokenCache) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.source == nil {
+		return "", nil
+	}
+
+	if len(t.token) != 0 && (t.expiry.IsZero() || t.now().Before(t.expiry.Add(-DefaultTokenExpiryLeeway))) {
+		return t.token, nil
+	}
+
+	token, err := t.source.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	t.token = token
+	t.expiry, _ = tokenExpiry(token)
+	return token, nil
+}
+
+// hasSource reports whether a TokenSource is defined
+func (t *tokenCache) hasSource() bool {
+	if t == nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.source != nil
+}
+
+// setSource replaces the TokenSource, forgetting the cached token
+func (t *tokenCache) setSource(source TokenSource) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.source = source
+	t.token = ""
+	t.expiry = time.Time{}
+}
+
+// invalidate forgets the token, unless it was already refreshed by another request
+func (t *tokenCache) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = ""
+		t.expiry = time.Time{}
+	}
+}
+
+// tokenExpiry reads the 'exp' claim of a JWT, without verifying its signature
+func tokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, err
+	}
+
+	if claims.Exp == nil {
+		return time.Time{}, errors.New("token has no 'exp' claim")
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(int64(exp), 0), nil
+}
+
+// token returns the token sent with requests, empty if none
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.tokens == nil {
+		return c.options.Token, nil
+	}
+	return c.tokens.Token(ctx)
+}
+
+// hasToken reports whether a token or TokenSource is defined
+func (c *Client) hasToken() bool {
+	return c.tokens.hasSource() || len(c.options.Token) != 0
+}
+
+// adoptToken replaces the token used by the following requests
+//
+// The adopted token can't be refreshed, so it replaces the TokenSource
+func (c *Client) adoptToken(token string) {
+	c.logger.Debug("using the token returned by the auth API")
+	c.tokens.setSource(StaticTokenSource(token))
+}
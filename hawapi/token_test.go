@@ -0,0 +1,169 @@
+package hawapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testJWT creates an unsigned JWT expiring at exp
+func testJWT(exp time.Time) string {
+	payload := fmt.Sprintf(`{"sub":"test","exp":%d}`, exp.Unix())
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func Test_tokenExpiry(t *testing.T) {
+	exp := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name    string
+		token   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "should read the exp claim",
+			token: testJWT(exp),
+			want:  exp,
+		},
+		{
+			name:    "should fail without exp claim",
+			token:   "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"test"}`)) + ".signature",
+			wantErr: true,
+		},
+		{
+			name:    "should fail with an opaque token",
+			token:   "token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tokenExpiry(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tokenExpiry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("tokenExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tokenCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	var calls int
+	tokens := newTokenCache(TokenSourceFunc(func(context.Context) (string, error) {
+		calls++
+		return testJWT(now.Add(time.Hour)), nil
+	}))
+	tokens.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	first, _ := tokens.Token(ctx)
+	tokens.Token(ctx)
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (token should be cached)", calls)
+	}
+
+	// Refreshed before it expires
+	now = now.Add(time.Hour - DefaultTokenExpiryLeeway)
+	tokens.Token(ctx)
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 (token should be refreshed)", calls)
+	}
+
+	// Already refreshed, so the old token is not invalidated
+	tokens.invalidate(first)
+	tokens.Token(ctx)
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 (token should not be invalidated)", calls)
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FileTokenSource(path).Token(context.Background())
+	if err != nil || got != "token" {
+		t.Errorf("Token() = %v, %v, want token", got, err)
+	}
+
+	_, err = FileTokenSource(filepath.Join(t.TempDir(), "missing")).Token(context.Background())
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Token() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestClient_tokenRefresh(t *testing.T) {
+	var valid atomic.Value
+	valid.Store("second")
+
+	var requests atomic.Int32
+	sv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer "+valid.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code": 401, "message": "Unauthorized"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer sv.Close()
+
+	var issued atomic.Int32
+	c := NewClientWithOpts(Options{
+		Endpoint:   sv.URL,
+		LogHandler: defaultTestLoggerHandler,
+		TokenSource: TokenSourceFunc(func(context.Context) (string, error) {
+			if issued.Add(1) == 1 {
+				return "first", nil
+			}
+			return "second", nil
+		}),
+	})
+
+	if _, err := c.CreateActor(CreateActor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests.Load() != 2 || issued.Load() != 2 {
+		t.Errorf("requests = %d, issued = %d, want 2 and 2", requests.Load(), issued.Load())
+	}
+
+	// The refreshed token is reused
+	if _, err := c.CreateActor(CreateActor{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests.Load() != 3 || issued.Load() != 2 {
+		t.Errorf("requests = %d, issued = %d, want 3 and 2", requests.Load(), issued.Load())
+	}
+
+	// A static token is rejected without retry
+	requests.Store(0)
+	c.WithOpts(Options{TokenSource: StaticTokenSource("first")})
+
+	_, err := c.CreateActor(CreateActor{})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("CreateActor() error = %v, want %v", err, ErrUnauthorized)
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("requests = %d, want 1", requests.Load())
+	}
+}
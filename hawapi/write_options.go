@@ -0,0 +1,87 @@
+package hawapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type writeOptions struct {
+	ifMatch string
+	strict  bool
+}
+
+// WriteOptions customizes a PATCH or DELETE request
+type WriteOptions func(*writeOptions)
+
+// WithIfMatch only applies the request if the item still has the ETag (e.g. ItemResponse.Etag),
+// failing with ErrPreconditionFailed otherwise
+//
+// By default, the ETag of the cached item is used, if any. Without cached item
+// (e.g. UseInMemoryCache is false) nor WithIfMatch, no precondition is sent, unless WithStrictIfMatch is used
+func WithIfMatch(etag string) WriteOptions {
+	return func(o *writeOptions) {
+		o.ifMatch = etag
+	}
+}
+
+// WithStrictIfMatch always sends a precondition: without WithIfMatch nor cached item,
+// the current ETag of the item is fetched first, bypassing the cache
+func WithStrictIfMatch() WriteOptions {
+	return func(o *writeOptions) {
+		o.strict = true
+	}
+}
+
+// setIfMatch sets the 'If-Match' header, using the given ETag, the ETag of the cached item,
+// or in strict mode the ETag returned by the API
+func (c *Client) setIfMatch(req *http.Request, origin string, options []WriteOptions) error {
+	var opts writeOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if len(opts.ifMatch) == 0 && c.cache != nil {
+		if cached, ok := c.cache.Get(c.cacheKey(origin)); ok {
+			if cbr, ok := cached.(cachedBaseResponse); ok {
+				opts.ifMatch = cbr.Etag
+			}
+		}
+	}
+
+	if len(opts.ifMatch) == 0 && opts.strict {
+		etag, err := c.fetchETag(req.Context(), origin)
+		if err != nil {
+			return err
+		}
+		opts.ifMatch = etag
+	}
+
+	if len(opts.ifMatch) != 0 {
+		req.Header.Set(headerIfMatch, opts.ifMatch)
+	}
+
+	return nil
+}
+
+// fetchETag gets the current ETag of an item, bypassing the cache
+func (c *Client) fetchETag(ctx context.Context, origin string) (string, error) {
+	// Same url as the GET requests of the item
+	url := c.cacheKey(origin)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.doRequest(req, http.StatusOK, nil)
+	if err != nil {
+		return "", err
+	}
+
+	etag := res.Header.Get(apiHeaderEtag)
+	if len(etag) == 0 {
+		return "", fmt.Errorf("no etag returned for '%s', required by WithStrictIfMatch", url)
+	}
+
+	return etag, nil
+}
@@ -1,6 +1,13 @@
 package cache
 
+import (
+	"strings"
+	"sync"
+)
+
 // Cache is a simple key / value cache
+//
+// Implementations must be safe for concurrent use
 type Cache interface {
 	Get(key string) (any, bool)
 	Set(key string, value any)
@@ -9,12 +16,37 @@ type Cache interface {
 	Clear() int
 }
 
+// PrefixCache is a Cache supporting the deletion of all keys starting with a prefix
+type PrefixCache interface {
+	Cache
+
+	// DelPrefix will remove all keys starting with prefix, returning the number of removed keys
+	DelPrefix(prefix string) int
+}
+
+// RangeCache is a Cache supporting the iteration over its entries
+type RangeCache interface {
+	Cache
+
+	// Range will call f for every entry, until it returns false
+	Range(f func(key string, value any) bool)
+}
+
+// SizeCache is a Cache knowing the stored size of its entries, without reading their values
+type SizeCache interface {
+	Cache
+
+	// RangeSizes will call f with the key and stored size (in bytes) of every entry, until it returns false
+	RangeSizes(f func(key string, size int64) bool)
+}
+
 type memoryCache struct {
+	mu    sync.RWMutex
 	cache map[string]any
 }
 
-// NewMemoryCache creates a new Cache
-func NewMemoryCache() Cache {
+// NewMemoryCache creates a new unbounded Cache
+func NewMemoryCache() PrefixCache {
 	return &memoryCache{
 		cache: make(map[string]any),
 	}
@@ -22,27 +54,71 @@ func NewMemoryCache() Cache {
 
 // Get will try to get associated with a key from the cache, if present
 func (c *memoryCache) Get(key string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	v, ok := c.cache[key]
 	return v, ok
 }
 
 // Set will store a key-value pair in the cache
 func (c *memoryCache) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.cache[key] = value
 }
 
 // Del will remove a key and its associated value from the cache.
 func (c *memoryCache) Del(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	delete(c.cache, key)
 }
 
+// DelPrefix will remove all keys starting with prefix
+func (c *memoryCache) DelPrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for key := range c.cache {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.cache, key)
+			count++
+		}
+	}
+	return count
+}
+
+// Range will call f for every entry, until it returns false
+//
+// The cache is locked during the iteration, so f must not use the cache
+func (c *memoryCache) Range(f func(key string, value any) bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for key, value := range c.cache {
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
 // Size will return the current number of entries in the cache.
 func (c *memoryCache) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return len(c.cache)
 }
 
 // Clear will empty the cache, removing all stored key-value pairs.
 func (c *memoryCache) Clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	count := len(c.cache)
 	c.cache = make(map[string]any)
 	return count
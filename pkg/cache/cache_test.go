@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// jsonCodec stores any value as JSON, decoded values have the json.Unmarshal types
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (jsonCodec) Decode(data []byte) (any, error) {
+	var value any
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+func newTestDiskCache(t *testing.T) TTLCache {
+	c, err := NewDiskCache(DiskOptions{Dir: t.TempDir(), Codec: jsonCodec{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCache_concurrent(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache Cache
+	}{
+		{
+			name:  "memory cache",
+			cache: NewMemoryCache(),
+		},
+		{
+			name:  "lru cache",
+			cache: NewLRUCache(Options{MaxEntries: 64}),
+		},
+		{
+			name:  "sharded lru cache",
+			cache: NewLRUCache(Options{MaxEntries: 64, Shards: 8}),
+		},
+		{
+			name:  "disk cache",
+			cache: newTestDiskCache(t),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < 500; i++ {
+						key := strconv.Itoa((g * i) % 100)
+						tt.cache.Set(key, i)
+						tt.cache.Get(key)
+						if i%10 == 0 {
+							tt.cache.Del(key)
+						}
+						tt.cache.Size()
+					}
+				}()
+			}
+			wg.Wait()
+
+			tt.cache.Clear()
+			if got := tt.cache.Size(); got != 0 {
+				t.Errorf("Size() = %v, want %v", got, 0)
+			}
+		})
+	}
+}
+
+func TestShardedCache_maxEntries(t *testing.T) {
+	c := NewLRUCache(Options{MaxEntries: 100, Shards: 4})
+
+	for i := 0; i < 1000; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	// Each shard holds up to 25 entries
+	if got := c.Size(); got > 100 {
+		t.Errorf("Size() = %v, want at most %v", got, 100)
+	}
+}
+
+func TestCache_DelPrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache PrefixCache
+	}{
+		{
+			name:  "memory cache",
+			cache: NewMemoryCache(),
+		},
+		{
+			name:  "lru cache",
+			cache: NewLRUCache(Options{MaxEntries: 64}),
+		},
+		{
+			name:  "sharded lru cache",
+			cache: NewLRUCache(Options{MaxEntries: 64, Shards: 8}),
+		},
+		{
+			name:  "disk cache",
+			cache: newTestDiskCache(t),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"v1/actors", "v1/actors?page=2", "v1/actors/random", "v1/seasons", "v1/seasons?page=2"} {
+				tt.cache.Set(key, key)
+			}
+
+			if got := tt.cache.DelPrefix("v1/actors"); got != 3 {
+				t.Errorf("DelPrefix() = %v, want %v", got, 3)
+			}
+
+			if got := tt.cache.Size(); got != 2 {
+				t.Errorf("Size() = %v, want %v", got, 2)
+			}
+
+			if _, ok := tt.cache.Get("v1/seasons?page=2"); !ok {
+				t.Errorf("Get() should find 'v1/seasons?page=2'")
+			}
+		})
+	}
+}
@@ -0,0 +1,435 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// diskFileExt is the extension of the files holding entries
+	diskFileExt = ".entry"
+
+	// diskTempPrefix is the prefix of the files being written
+	diskTempPrefix = "tmp-"
+
+	// diskTempMaxAge is how long a file can be written, older ones were left by a crashed write
+	diskTempMaxAge = time.Hour
+)
+
+// Codec converts the values of a disk cache to bytes
+type Codec interface {
+	Encode(value any) ([]byte, error)
+	Decode(data []byte) (any, error)
+}
+
+// BytesCodec stores []byte and string values as is, and decodes them as []byte
+type BytesCodec struct{}
+
+func (BytesCodec) Encode(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
+
+func (BytesCodec) Decode(data []byte) (any, error) {
+	return data, nil
+}
+
+// DiskOptions defines where and how a disk cache stores its entries
+type DiskOptions struct {
+	// The directory holding the entries, created if missing
+	Dir string
+
+	// How long an entry is kept after being set
+	//
+	// Set it to 0 to never expire entries
+	TTL time.Duration
+
+	// The max size of all entries, in bytes. When exceeded, the least recently used entries are evicted
+	//
+	// Set it to 0 for an unbounded cache
+	MaxBytes int64
+
+	// Converts values to bytes
+	//
+	// Default value: BytesCodec
+	Codec Codec
+
+	// Called when an entry is evicted, because the cache is full or the entry expired
+	//
+	// Only the key is given, so evicting entries never reads their files.
+	// It is called while the cache is locked, so it must not use the cache
+	OnEvict func(key string)
+}
+
+// diskEntry is the content of an entry file
+type diskEntry struct {
+	Key       string    `json:"key"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	Value     []byte    `json:"value"`
+}
+
+func (e *diskEntry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// diskFile is the in-memory index of an entry file, so only reading values needs the file content
+type diskFile struct {
+	key       string
+	expiresAt time.Time
+	size      int64
+	accessed  time.Time
+}
+
+func (f *diskFile) expired(now time.Time) bool {
+	return !f.expiresAt.IsZero() && !now.Before(f.expiresAt)
+}
+
+type diskCache struct {
+	mu      sync.Mutex
+	options DiskOptions
+	files   map[string]diskFile
+	size    int64
+	now     func() time.Time
+}
+
+// NewDiskCache creates a new Cache storing each entry in its own file, kept between runs.
+//
+// Files are written atomically, so a cache directory can be shared by many processes,
+// but the MaxBytes limit is only applied to the entries known by each process.
+// I/O errors are handled as missing entries.
+func NewDiskCache(options DiskOptions) (TTLCache, error) {
+	if len(options.Dir) == 0 {
+		return nil, errors.New("cache: dir is required")
+	}
+
+	if options.Codec == nil {
+		options.Codec = BytesCodec{}
+	}
+
+	if err := os.MkdirAll(options.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+
+	c := &diskCache{
+		options: options,
+		files:   make(map[string]diskFile),
+		now:     time.Now,
+	}
+
+	// Index the entries of a previous run
+	dirEntries, err := os.ReadDir(options.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+
+	for _, de := range dirEntries {
+		if de.IsDir() {
+			continue
+		}
+
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+
+		if strings.HasPrefix(de.Name(), diskTempPrefix) {
+			if c.now().Sub(info.ModTime()) > diskTempMaxAge {
+				os.Remove(filepath.Join(options.Dir, de.Name()))
+			}
+			continue
+		}
+
+		if filepath.Ext(de.Name()) != diskFileExt {
+			continue
+		}
+
+		// Files are only read here, to index their key and expiry
+		if _, ok := c.read(de.Name()); ok {
+			file := c.files[de.Name()]
+			file.accessed = info.ModTime()
+			c.files[de.Name()] = file
+		}
+	}
+
+	c.mu.Lock()
+	c.shrink()
+	c.mu.Unlock()
+
+	return c, nil
+}
+
+// fileName returns the name of the file holding the key
+func fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + diskFileExt
+}
+
+// Get will try to get associated with a key from the cache, if present and not expired
+func (c *diskCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := fileName(key)
+	entry, ok := c.read(name)
+	if !ok || entry.Key != key {
+		return nil, false
+	}
+
+	if entry.expired(c.now()) {
+		c.evict(name)
+		return nil, false
+	}
+
+	value, err := c.options.Codec.Decode(entry.Value)
+	if err != nil {
+		c.remove(name)
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Set will store a key-value pair in the cache, using the default TTL
+func (c *diskCache) Set(key string, value any) {
+	c.SetWithTTL(key, value, c.options.TTL)
+}
+
+// SetWithTTL will store a key-value pair in the cache which expires after ttl
+func (c *diskCache) SetWithTTL(key string, value any, ttl time.Duration) {
+	data, err := c.options.Codec.Encode(value)
+	if err != nil {
+		return
+	}
+
+	entry := diskEntry{Key: key, Value: data}
+	if ttl > 0 {
+		entry.ExpiresAt = c.now().Add(ttl)
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := fileName(key)
+	if err := c.write(name, b); err != nil {
+		return
+	}
+
+	c.index(name, entry, int64(len(b)))
+
+	c.shrink()
+}
+
+// Del will remove a key and its associated value from the cache.
+func (c *diskCache) Del(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(fileName(key))
+}
+
+// DelPrefix will remove all keys starting with prefix
+func (c *diskCache) DelPrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for name, f := range c.files {
+		if strings.HasPrefix(f.key, prefix) {
+			c.remove(name)
+			count++
+		}
+	}
+	return count
+}
+
+// Range will call f for every non-expired entry, until it returns false
+//
+// Each value is read from its file. The cache is locked during the iteration, so f must not use the cache
+func (c *diskCache) Range(f func(key string, value any) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for name, file := range c.files {
+		if file.expired(now) {
+			continue
+		}
+
+		entry, ok := c.read(name)
+		if !ok || entry.expired(now) {
+			continue
+		}
+
+		value, err := c.options.Codec.Decode(entry.Value)
+		if err != nil {
+			continue
+		}
+
+		if !f(entry.Key, value) {
+			return
+		}
+	}
+}
+
+// RangeSizes will call f with the key and file size of every non-expired entry, until it returns false
+//
+// Only the index is used, files are not read. The cache is locked during the iteration, so f must not use the cache
+func (c *diskCache) RangeSizes(f func(key string, size int64) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, file := range c.files {
+		if file.expired(now) {
+			continue
+		}
+
+		if !f(file.key, file.size) {
+			return
+		}
+	}
+}
+
+// Size will return the current number of non-expired entries in the cache.
+func (c *diskCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for name, f := range c.files {
+		if f.expired(now) {
+			c.evict(name)
+		}
+	}
+
+	return len(c.files)
+}
+
+// Clear will empty the cache, removing all stored key-value pairs.
+func (c *diskCache) Clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := len(c.files)
+	for name := range c.files {
+		c.remove(name)
+	}
+	return count
+}
+
+// read returns the entry of a file, updating its index
+// (the file may have been written or removed by another process)
+func (c *diskCache) read(name string) (diskEntry, bool) {
+	var entry diskEntry
+
+	b, err := os.ReadFile(filepath.Join(c.options.Dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		c.forget(name)
+		return entry, false
+	}
+
+	if err != nil || json.Unmarshal(b, &entry) != nil {
+		return entry, false
+	}
+
+	c.index(name, entry, int64(len(b)))
+	return entry, true
+}
+
+// index adds or updates the index of a file, marking it as accessed
+func (c *diskCache) index(name string, entry diskEntry, size int64) {
+	c.size += size - c.files[name].size
+	c.files[name] = diskFile{key: entry.Key, expiresAt: entry.ExpiresAt, size: size, accessed: c.now()}
+}
+
+// write replaces the file atomically, so readers never see a partial entry
+func (c *diskCache) write(name string, b []byte) error {
+	tmp, err := os.CreateTemp(c.options.Dir, diskTempPrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(c.options.Dir, name)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
+
+// shrink evicts the least recently used entries, until the cache fits in MaxBytes
+func (c *diskCache) shrink() {
+	if c.options.MaxBytes <= 0 || c.size <= c.options.MaxBytes {
+		return
+	}
+
+	names := make([]string, 0, len(c.files))
+	for name := range c.files {
+		names = append(names, name)
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		return c.files[a].accessed.Compare(c.files[b].accessed)
+	})
+
+	for _, name := range names {
+		if c.size <= c.options.MaxBytes {
+			return
+		}
+
+		c.evict(name)
+	}
+}
+
+// evict removes the file, notifying OnEvict
+func (c *diskCache) evict(name string) {
+	key := c.files[name].key
+	c.remove(name)
+
+	if c.options.OnEvict != nil && len(key) != 0 {
+		c.options.OnEvict(key)
+	}
+}
+
+func (c *diskCache) remove(name string) {
+	os.Remove(filepath.Join(c.options.Dir, name))
+	c.forget(name)
+}
+
+func (c *diskCache) forget(name string) {
+	if f, ok := c.files[name]; ok {
+		c.size -= f.size
+		delete(c.files, name)
+	}
+}
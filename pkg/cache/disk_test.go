@@ -0,0 +1,189 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := NewDiskCache(DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("v1/actors", []byte("lorem"))
+	c.Set("v1/actors?page=2", "ipsum")
+	c.Set("v1/seasons", []byte("dolor"))
+
+	if v, ok := c.Get("v1/actors"); !ok || string(v.([]byte)) != "lorem" {
+		t.Errorf("Get() = %v, %v, want lorem", v, ok)
+	}
+
+	// Entries are kept between runs
+	c, err = NewDiskCache(DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Size(); got != 3 {
+		t.Errorf("Size() = %v, want %v", got, 3)
+	}
+
+	if v, ok := c.Get("v1/actors?page=2"); !ok || string(v.([]byte)) != "ipsum" {
+		t.Errorf("Get() = %v, %v, want ipsum", v, ok)
+	}
+
+	// Sizes and prefixes only use the index, not the file contents
+	if err := os.WriteFile(filepath.Join(dir, fileName("v1/actors")), []byte("corrupted"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Size(); got != 3 {
+		t.Errorf("Size() = %v, want %v", got, 3)
+	}
+
+	var keys []string
+	c.(SizeCache).RangeSizes(func(key string, size int64) bool {
+		if size <= 0 {
+			t.Errorf("RangeSizes() %s size = %v", key, size)
+		}
+		keys = append(keys, key)
+		return true
+	})
+
+	slices.Sort(keys)
+	if want := []string{"v1/actors", "v1/actors?page=2", "v1/seasons"}; !slices.Equal(keys, want) {
+		t.Errorf("RangeSizes() keys = %v, want %v", keys, want)
+	}
+
+	if got := c.DelPrefix("v1/actors"); got != 2 {
+		t.Errorf("DelPrefix() = %v, want %v", got, 2)
+	}
+
+	c.Del("v1/seasons")
+	if _, ok := c.Get("v1/seasons"); ok {
+		t.Error("Get(v1/seasons) should have been deleted")
+	}
+
+	// No temporary file is left
+	files, _ := os.ReadDir(dir)
+	if len(files) != 0 {
+		t.Errorf("files = %v, want none", files)
+	}
+}
+
+func TestDiskCache_ttl(t *testing.T) {
+	now := time.Now()
+
+	var evicted []string
+	tc, err := NewDiskCache(DiskOptions{
+		Dir: t.TempDir(),
+		TTL: time.Minute,
+		OnEvict: func(key string) {
+			evicted = append(evicted, key)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := tc.(*diskCache)
+	c.now = func() time.Time { return now }
+
+	c.Set("default", []byte("1"))
+	c.SetWithTTL("short", []byte("2"), time.Second)
+	c.SetWithTTL("forever", []byte("3"), 0)
+
+	now = now.Add(2 * time.Second)
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get(short) should have expired")
+	}
+
+	now = now.Add(time.Hour)
+
+	if got := c.Size(); got != 1 {
+		t.Errorf("Size() = %v, want %v", got, 1)
+	}
+
+	if want := []string{"short", "default"}; !reflect.DeepEqual(evicted, want) {
+		t.Errorf("evicted = %v, want %v", evicted, want)
+	}
+}
+
+func TestDiskCache_maxBytes(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+
+	tc, err := NewDiskCache(DiskOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := tc.(*diskCache)
+	c.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b", "c"} {
+		now = now.Add(time.Second)
+		c.Set(key, make([]byte, 100))
+	}
+
+	// 'a' is now the most recently used entry
+	now = now.Add(time.Second)
+	c.Get("a")
+
+	entrySize := c.size / 3
+	c.options.MaxBytes = 2 * entrySize
+	c.Set("d", make([]byte, 100))
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": false, "d": true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("Get(%s) present = %v, want %v", key, ok, want)
+		}
+	}
+
+	if c.size > c.options.MaxBytes {
+		t.Errorf("size = %v, want at most %v", c.size, c.options.MaxBytes)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+diskFileExt))
+	if len(files) != 2 {
+		t.Errorf("files = %v, want 2", files)
+	}
+}
+
+func TestDiskCache_tempFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	// Left by a crashed write, or being written by another process
+	stale := filepath.Join(dir, diskTempPrefix+"stale")
+	writing := filepath.Join(dir, diskTempPrefix+"writing")
+	for _, name := range []string{stale, writing} {
+		if err := os.WriteFile(name, []byte("lorem"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old := time.Now().Add(-2 * diskTempMaxAge)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewDiskCache(DiskOptions{Dir: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale temp file should have been removed, got %v", err)
+	}
+
+	if _, err := os.Stat(writing); err != nil {
+		t.Errorf("recent temp file should have been kept, got %v", err)
+	}
+}
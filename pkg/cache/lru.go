@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options defines the limits of a bounded cache
+type Options struct {
+	// The max number of entries. When exceeded, the least recently used entry is evicted
+	//
+	// Set it to 0 for an unbounded cache
+	MaxEntries int
+
+	// How long an entry is kept after being set
+	//
+	// Set it to 0 to never expire entries
+	TTL time.Duration
+
+	// The number of independently locked shards, reducing lock contention between goroutines
+	//
+	// The MaxEntries limit is split between shards, so the LRU order is only exact with 0 or 1 shard
+	Shards int
+
+	// Called when an entry is evicted, because the cache is full or the entry expired
+	//
+	// It is called while the cache is locked, so it must not use the cache
+	OnEvict func(key string, value any)
+}
+
+// TTLCache is a Cache supporting a custom TTL per entry, and prefix deletion
+type TTLCache interface {
+	PrefixCache
+
+	// SetWithTTL will store a key-value pair which expires after ttl (0 means never)
+	SetWithTTL(key string, value any, ttl time.Duration)
+}
+
+type lruEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+func (e *lruEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+type lruCache struct {
+	mu      sync.Mutex
+	options Options
+	items   map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+// NewLRUCache creates a new bounded Cache, evicting the least recently used and expired entries
+func NewLRUCache(options Options) TTLCache {
+	if options.Shards > 1 {
+		return newShardedCache(options)
+	}
+
+	return newLRUCache(options)
+}
+
+func newLRUCache(options Options) *lruCache {
+	return &lruCache{
+		options: options,
+		items:   make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get will try to get associated with a key from the cache, if present and not expired
+func (c *lruCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if entry.expired(c.now()) {
+		c.evict(el)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set will store a key-value pair in the cache, using the default TTL
+func (c *lruCache) Set(key string, value any) {
+	c.SetWithTTL(key, value, c.options.TTL)
+}
+
+// SetWithTTL will store a key-value pair in the cache which expires after ttl
+func (c *lruCache) SetWithTTL(key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	if c.options.MaxEntries > 0 && c.order.Len() > c.options.MaxEntries {
+		c.evict(c.order.Back())
+	}
+}
+
+// Del will remove a key and its associated value from the cache.
+func (c *lruCache) Del(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+// DelPrefix will remove all keys starting with prefix
+func (c *lruCache) DelPrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for key, el := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(el)
+			count++
+		}
+	}
+	return count
+}
+
+// Range will call f for every non-expired entry, until it returns false
+//
+// The cache is locked during the iteration, so f must not use the cache
+func (c *lruCache) Range(f func(key string, value any) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		entry := el.Value.(*lruEntry)
+		if entry.expired(now) {
+			continue
+		}
+
+		if !f(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// Size will return the current number of non-expired entries in the cache.
+func (c *lruCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for el := c.order.Back(); el != nil; {
+		prev := el.Prev()
+		if el.Value.(*lruEntry).expired(now) {
+			c.evict(el)
+		}
+		el = prev
+	}
+
+	return c.order.Len()
+}
+
+// Clear will empty the cache, removing all stored key-value pairs.
+func (c *lruCache) Clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := c.order.Len()
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+	return count
+}
+
+// evict removes the entry, notifying OnEvict
+func (c *lruCache) evict(el *list.Element) {
+	c.remove(el)
+
+	if c.options.OnEvict != nil {
+		entry := el.Value.(*lruEntry)
+		c.options.OnEvict(entry.key, entry.value)
+	}
+}
+
+func (c *lruCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*lruEntry).key)
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLRUCache_eviction(t *testing.T) {
+	c := NewLRUCache(Options{MaxEntries: 2})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	// 'a' is now the most recently used entry
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) should be present")
+	}
+
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) should have been evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%s) should be present", key)
+		}
+	}
+
+	if got := c.Size(); got != 2 {
+		t.Errorf("Size() = %v, want %v", got, 2)
+	}
+}
+
+func TestLRUCache_ttl(t *testing.T) {
+	now := time.Now()
+	c := newLRUCache(Options{TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.Set("default", 1)
+	c.SetWithTTL("short", 2, time.Second)
+	c.SetWithTTL("forever", 3, 0)
+
+	now = now.Add(2 * time.Second)
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get(short) should have expired")
+	}
+
+	if v, ok := c.Get("default"); !ok || v != 1 {
+		t.Errorf("Get(default) = %v, %v, want %v", v, ok, 1)
+	}
+
+	now = now.Add(time.Hour)
+
+	if got := c.Size(); got != 1 {
+		t.Errorf("Size() = %v, want %v", got, 1)
+	}
+
+	if v, ok := c.Get("forever"); !ok || v != 3 {
+		t.Errorf("Get(forever) = %v, %v, want %v", v, ok, 3)
+	}
+}
+
+func TestLRUCache_Clear(t *testing.T) {
+	c := NewLRUCache(Options{})
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Del("a")
+
+	if got := c.Clear(); got != 1 {
+		t.Errorf("Clear() = %v, want %v", got, 1)
+	}
+
+	if got := c.Size(); got != 0 {
+		t.Errorf("Size() = %v, want %v", got, 0)
+	}
+}
+
+func TestLRUCache_OnEvict(t *testing.T) {
+	now := time.Now()
+
+	var evicted []string
+	c := newLRUCache(Options{
+		MaxEntries: 2,
+		OnEvict: func(key string, value any) {
+			evicted = append(evicted, key)
+		},
+	})
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, time.Second)
+	c.Set("c", 3)
+	c.Del("c")
+
+	now = now.Add(2 * time.Second)
+	c.Get("b")
+
+	// Deleted entries are not evicted
+	if want := []string{"a", "b"}; !reflect.DeepEqual(evicted, want) {
+		t.Errorf("evicted = %v, want %v", evicted, want)
+	}
+}
+
+func TestLRUCache_Range(t *testing.T) {
+	now := time.Now()
+	c := newLRUCache(Options{})
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, time.Second)
+	c.Set("c", 3)
+
+	now = now.Add(2 * time.Second)
+
+	got := make(map[string]any)
+	c.Range(func(key string, value any) bool {
+		got[key] = value
+		return true
+	})
+
+	if want := map[string]any{"a": 1, "c": 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Range() = %v, want %v", got, want)
+	}
+}
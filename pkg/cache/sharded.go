@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// shardedCache spreads keys between many lruCache, each one with its own lock
+type shardedCache struct {
+	shards []*lruCache
+}
+
+func newShardedCache(options Options) *shardedCache {
+	shardOptions := options
+	if options.MaxEntries > 0 {
+		// Round up, so the cache holds at least MaxEntries
+		shardOptions.MaxEntries = (options.MaxEntries + options.Shards - 1) / options.Shards
+	}
+
+	c := &shardedCache{
+		shards: make([]*lruCache, options.Shards),
+	}
+
+	for i := range c.shards {
+		c.shards[i] = newLRUCache(shardOptions)
+	}
+
+	return c
+}
+
+func (c *shardedCache) shard(key string) *lruCache {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// Get will try to get associated with a key from the cache, if present and not expired
+func (c *shardedCache) Get(key string) (any, bool) {
+	return c.shard(key).Get(key)
+}
+
+// Set will store a key-value pair in the cache, using the default TTL
+func (c *shardedCache) Set(key string, value any) {
+	c.shard(key).Set(key, value)
+}
+
+// SetWithTTL will store a key-value pair in the cache which expires after ttl
+func (c *shardedCache) SetWithTTL(key string, value any, ttl time.Duration) {
+	c.shard(key).SetWithTTL(key, value, ttl)
+}
+
+// Del will remove a key and its associated value from the cache.
+func (c *shardedCache) Del(key string) {
+	c.shard(key).Del(key)
+}
+
+// DelPrefix will remove all keys starting with prefix, from every shard
+func (c *shardedCache) DelPrefix(prefix string) int {
+	count := 0
+	for _, s := range c.shards {
+		count += s.DelPrefix(prefix)
+	}
+	return count
+}
+
+// Range will call f for every non-expired entry, until it returns false
+//
+// Shards are locked one after the other, so f must not use the cache
+func (c *shardedCache) Range(f func(key string, value any) bool) {
+	for _, s := range c.shards {
+		more := true
+		s.Range(func(key string, value any) bool {
+			more = f(key, value)
+			return more
+		})
+
+		if !more {
+			return
+		}
+	}
+}
+
+// Size will return the current number of non-expired entries in the cache.
+func (c *shardedCache) Size() int {
+	size := 0
+	for _, s := range c.shards {
+		size += s.Size()
+	}
+	return size
+}
+
+// Clear will empty the cache, removing all stored key-value pairs.
+func (c *shardedCache) Clear() int {
+	count := 0
+	for _, s := range c.shards {
+		count += s.Clear()
+	}
+	return count
+}